@@ -0,0 +1,545 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Uygulama ayarları. Değerler düşükten yükseğe şu sırayla okunur:
+// varsayılanlar < yapılandırma dosyası (JSON) < .env dosyası < ortam değişkenleri < komut satırı bayrakları
+type Config struct {
+	ListenAddr       string   `json:"listen_addr"`
+	SessionTTL       Duration `json:"session_ttl"`
+	ImpersonationTTL Duration `json:"impersonation_ttl"`
+	IdempotencyTTL   Duration `json:"idempotency_ttl"`
+	TrashRetention   Duration `json:"trash_retention"`
+	RBACPolicyFile   string   `json:"rbac_policy_file"`
+	Storage          string   `json:"storage"`
+	CORSOrigins      []string `json:"cors_origins"`
+	LogLevel         string   `json:"log_level"`
+
+	// İstemci IP'si yalnızca bu adres veya CIDR'lerden gelen X-Forwarded-For başlığından okunur; boşsa hiçbir proxy'ye güvenilmez
+	TrustedProxies []string `json:"trusted_proxies"`
+
+	// JWT imzası: algoritma, anahtar yenileme aralığı (0 kapatır) ve isteğe bağlı PEM özel anahtar dosyası
+	JWTAlgorithm      string   `json:"jwt_algorithm"`
+	JWTKeyRotation    Duration `json:"jwt_key_rotation"`
+	JWTPrivateKeyFile string   `json:"jwt_private_key_file"`
+
+	// CORS ve güvenlik başlıkları; HSTSMaxAge 0 ise Strict-Transport-Security gönderilmez
+	CORSMethods          []string `json:"cors_methods"`
+	CORSAllowCredentials bool     `json:"cors_allow_credentials"`
+	CORSMaxAge           Duration `json:"cors_max_age"`
+	HSTSMaxAge           Duration `json:"hsts_max_age"`
+
+	// HTTP sunucusu sınırları
+	ReadTimeout     Duration `json:"read_timeout"`
+	WriteTimeout    Duration `json:"write_timeout"`
+	IdleTimeout     Duration `json:"idle_timeout"`
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+	MaxHeaderBytes  int      `json:"max_header_bytes"`
+	MaxBodyBytes    int64    `json:"max_body_bytes"`
+
+	// İstek sınırları (saniyede istek ve anlık en fazla istek); 0 sınırı kapatır
+	RateLimitIPRPS      float64 `json:"rate_limit_ip_rps"`
+	RateLimitIPBurst    int     `json:"rate_limit_ip_burst"`
+	RateLimitUserRPS    float64 `json:"rate_limit_user_rps"`
+	RateLimitUserBurst  int     `json:"rate_limit_user_burst"`
+	LoginRateLimitRPS   float64 `json:"login_rate_limit_rps"`
+	LoginRateLimitBurst int     `json:"login_rate_limit_burst"`
+
+	// Kullanıcı adı başına başarısız giriş kilidi
+	LoginLockoutThreshold int      `json:"login_lockout_threshold"`
+	LoginLockoutBase      Duration `json:"login_lockout_base"`
+	LoginLockoutMax       Duration `json:"login_lockout_max"`
+
+	// İzleme (tracing) span'larının gönderileceği yer
+	TracingExporter string `json:"tracing_exporter"`
+	OTLPEndpoint    string `json:"otlp_endpoint"`
+}
+
+// JSON'da "72h" gibi yazılan süre
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.New("duration must be a string such as \"72h\"")
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Desteklenen depolama türleri, log seviyeleri ve diğer seçenekler
+var (
+	storageBackends = []string{"memory"}
+	logLevels       = []string{"debug", "info", "warn", "error"}
+	tracingExports  = []string{"none", "stdout", "otlp"}
+	corsMethods     = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+	jwtAlgorithms   = []string{"RS256", "EdDSA"}
+)
+
+func Default() *Config {
+	return &Config{
+		ListenAddr:       ":8081",
+		SessionTTL:       Duration{72 * time.Hour},
+		ImpersonationTTL: Duration{30 * time.Minute},
+		IdempotencyTTL:   Duration{24 * time.Hour},
+		TrashRetention:   Duration{30 * 24 * time.Hour},
+		Storage:          "memory",
+		LogLevel:         "info",
+		JWTAlgorithm:     "EdDSA",
+		JWTKeyRotation:   Duration{24 * time.Hour},
+		CORSMethods:      []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		CORSMaxAge:       Duration{10 * time.Minute},
+		HSTSMaxAge:       Duration{365 * 24 * time.Hour},
+		ReadTimeout:      Duration{15 * time.Second},
+		WriteTimeout:     Duration{30 * time.Second},
+		IdleTimeout:      Duration{2 * time.Minute},
+		ShutdownTimeout:  Duration{20 * time.Second},
+		MaxHeaderBytes:   64 << 10,
+		MaxBodyBytes:     1 << 20,
+		TracingExporter:  "none",
+		OTLPEndpoint:     "http://localhost:4318",
+
+		RateLimitIPRPS:        20,
+		RateLimitIPBurst:      40,
+		RateLimitUserRPS:      10,
+		RateLimitUserBurst:    20,
+		LoginRateLimitRPS:     0.2,
+		LoginRateLimitBurst:   5,
+		LoginLockoutThreshold: 5,
+		LoginLockoutBase:      Duration{time.Minute},
+		LoginLockoutMax:       Duration{time.Hour},
+	}
+}
+
+// Ayarları varsayılanlar, dosya, .env, ortam değişkenleri ve args'taki bayraklardan okur ve doğrular
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("priviatodolist", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := fs.String("config", "", "JSON yapılandırma dosyası (CONFIG_FILE)")
+	listen := fs.String("listen", "", "dinlenecek adres, örn. :8081 (LISTEN_ADDR)")
+	sessionTTL := fs.Duration("session-ttl", 0, "oturum süresi (SESSION_TTL)")
+	impersonationTTL := fs.Duration("impersonation-ttl", 0, "impersonation oturum süresi (IMPERSONATION_TTL)")
+	idempotencyTTL := fs.Duration("idempotency-ttl", 0, "Idempotency-Key saklama süresi (IDEMPOTENCY_TTL)")
+	trashRetention := fs.Duration("trash-retention", 0, "çöp kutusu saklama süresi (TRASH_RETENTION)")
+	rbacPolicy := fs.String("rbac-policy", "", "rol -> izin JSON dosyası (RBAC_POLICY_FILE)")
+	storage := fs.String("storage", "", "depolama türü (STORAGE_BACKEND)")
+	corsOrigins := fs.String("cors-origins", "", "virgülle ayrılmış izinli origin'ler (CORS_ORIGINS)")
+	trustedProxies := fs.String("trusted-proxies", "", "virgülle ayrılmış güvenilen proxy adres veya CIDR'leri (TRUSTED_PROXIES)")
+	corsMethods := fs.String("cors-methods", "", "virgülle ayrılmış izinli CORS yöntemleri (CORS_METHODS)")
+	corsAllowCredentials := fs.Bool("cors-allow-credentials", false, "CORS isteklerinde çerez ve kimlik bilgisine izin ver (CORS_ALLOW_CREDENTIALS)")
+	corsMaxAge := fs.Duration("cors-max-age", 0, "preflight yanıtlarının önbellekte tutulma süresi (CORS_MAX_AGE)")
+	hstsMaxAge := fs.Duration("hsts-max-age", 0, "Strict-Transport-Security max-age, 0 kapatır (HSTS_MAX_AGE)")
+	jwtAlgorithm := fs.String("jwt-algorithm", "", "RS256 veya EdDSA (JWT_ALGORITHM)")
+	jwtKeyRotation := fs.Duration("jwt-key-rotation", 0, "JWT imza anahtarı yenileme aralığı, 0 kapatır (JWT_KEY_ROTATION)")
+	jwtPrivateKeyFile := fs.String("jwt-private-key", "", "PEM JWT özel anahtar dosyası (JWT_PRIVATE_KEY_FILE)")
+	logLevel := fs.String("log-level", "", "debug, info, warn veya error (LOG_LEVEL)")
+	readTimeout := fs.Duration("read-timeout", 0, "istek okuma zaman aşımı (READ_TIMEOUT)")
+	writeTimeout := fs.Duration("write-timeout", 0, "yanıt yazma zaman aşımı (WRITE_TIMEOUT)")
+	idleTimeout := fs.Duration("idle-timeout", 0, "boşta bağlantı zaman aşımı (IDLE_TIMEOUT)")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 0, "kapanışta isteklerin bitmesi için beklenecek süre (SHUTDOWN_TIMEOUT)")
+	maxHeaderBytes := fs.Int("max-header-bytes", 0, "en büyük istek başlığı boyutu (MAX_HEADER_BYTES)")
+	maxBodyBytes := fs.Int64("max-body-bytes", 0, "en büyük istek gövdesi boyutu (MAX_BODY_BYTES)")
+	rateLimitIPRPS := fs.Float64("rate-limit-ip-rps", 0, "IP başına saniyede istek (RATE_LIMIT_IP_RPS)")
+	rateLimitIPBurst := fs.Int("rate-limit-ip-burst", 0, "IP başına anlık en fazla istek (RATE_LIMIT_IP_BURST)")
+	rateLimitUserRPS := fs.Float64("rate-limit-user-rps", 0, "kullanıcı başına saniyede istek (RATE_LIMIT_USER_RPS)")
+	rateLimitUserBurst := fs.Int("rate-limit-user-burst", 0, "kullanıcı başına anlık en fazla istek (RATE_LIMIT_USER_BURST)")
+	loginRateLimitRPS := fs.Float64("login-rate-limit-rps", 0, "IP başına saniyede login isteği (LOGIN_RATE_LIMIT_RPS)")
+	loginRateLimitBurst := fs.Int("login-rate-limit-burst", 0, "IP başına anlık en fazla login isteği (LOGIN_RATE_LIMIT_BURST)")
+	loginLockoutThreshold := fs.Int("login-lockout-threshold", 0, "kilitten önce izin verilen başarısız giriş (LOGIN_LOCKOUT_THRESHOLD)")
+	loginLockoutBase := fs.Duration("login-lockout-base", 0, "ilk kilit süresi (LOGIN_LOCKOUT_BASE)")
+	loginLockoutMax := fs.Duration("login-lockout-max", 0, "en uzun kilit süresi (LOGIN_LOCKOUT_MAX)")
+	tracingExporter := fs.String("tracing-exporter", "", "none, stdout veya otlp (TRACING_EXPORTER)")
+	otlpEndpoint := fs.String("otlp-endpoint", "", "OTLP/HTTP toplayıcı adresi (OTEL_EXPORTER_OTLP_ENDPOINT)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	env, err := environment()
+	if err != nil {
+		return nil, err
+	}
+
+	path := env["CONFIG_FILE"]
+	if *configFile != "" {
+		path = *configFile
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(env); err != nil {
+		return nil, err
+	}
+
+	// Yalnızca açıkça verilen bayraklar önceki değerlerin yerine geçer
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen":
+			cfg.ListenAddr = *listen
+		case "session-ttl":
+			cfg.SessionTTL.Duration = *sessionTTL
+		case "impersonation-ttl":
+			cfg.ImpersonationTTL.Duration = *impersonationTTL
+		case "idempotency-ttl":
+			cfg.IdempotencyTTL.Duration = *idempotencyTTL
+		case "trash-retention":
+			cfg.TrashRetention.Duration = *trashRetention
+		case "rbac-policy":
+			cfg.RBACPolicyFile = *rbacPolicy
+		case "storage":
+			cfg.Storage = *storage
+		case "cors-origins":
+			cfg.CORSOrigins = splitList(*corsOrigins)
+		case "trusted-proxies":
+			cfg.TrustedProxies = splitList(*trustedProxies)
+		case "cors-methods":
+			cfg.CORSMethods = splitList(strings.ToUpper(*corsMethods))
+		case "cors-allow-credentials":
+			cfg.CORSAllowCredentials = *corsAllowCredentials
+		case "cors-max-age":
+			cfg.CORSMaxAge.Duration = *corsMaxAge
+		case "hsts-max-age":
+			cfg.HSTSMaxAge.Duration = *hstsMaxAge
+		case "jwt-algorithm":
+			cfg.JWTAlgorithm = *jwtAlgorithm
+		case "jwt-key-rotation":
+			cfg.JWTKeyRotation.Duration = *jwtKeyRotation
+		case "jwt-private-key":
+			cfg.JWTPrivateKeyFile = *jwtPrivateKeyFile
+		case "log-level":
+			cfg.LogLevel = *logLevel
+		case "read-timeout":
+			cfg.ReadTimeout.Duration = *readTimeout
+		case "write-timeout":
+			cfg.WriteTimeout.Duration = *writeTimeout
+		case "idle-timeout":
+			cfg.IdleTimeout.Duration = *idleTimeout
+		case "shutdown-timeout":
+			cfg.ShutdownTimeout.Duration = *shutdownTimeout
+		case "max-header-bytes":
+			cfg.MaxHeaderBytes = *maxHeaderBytes
+		case "max-body-bytes":
+			cfg.MaxBodyBytes = *maxBodyBytes
+		case "rate-limit-ip-rps":
+			cfg.RateLimitIPRPS = *rateLimitIPRPS
+		case "rate-limit-ip-burst":
+			cfg.RateLimitIPBurst = *rateLimitIPBurst
+		case "rate-limit-user-rps":
+			cfg.RateLimitUserRPS = *rateLimitUserRPS
+		case "rate-limit-user-burst":
+			cfg.RateLimitUserBurst = *rateLimitUserBurst
+		case "login-rate-limit-rps":
+			cfg.LoginRateLimitRPS = *loginRateLimitRPS
+		case "login-rate-limit-burst":
+			cfg.LoginRateLimitBurst = *loginRateLimitBurst
+		case "login-lockout-threshold":
+			cfg.LoginLockoutThreshold = *loginLockoutThreshold
+		case "login-lockout-base":
+			cfg.LoginLockoutBase.Duration = *loginLockoutBase
+		case "login-lockout-max":
+			cfg.LoginLockoutMax.Duration = *loginLockoutMax
+		case "tracing-exporter":
+			cfg.TracingExporter = *tracingExporter
+		case "otlp-endpoint":
+			cfg.OTLPEndpoint = *otlpEndpoint
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Ortam değişkenleri; .env dosyası varsa ortamda tanımlı olmayan değerler oradan alınır
+func environment() (map[string]string, error) {
+	env := map[string]string{}
+	if _, err := os.Stat(".env"); err == nil {
+		values, err := godotenv.Read(".env")
+		if err != nil {
+			return nil, fmt.Errorf("reading .env: %w", err)
+		}
+		for k, v := range values {
+			env[k] = v
+		}
+	}
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+	return env, nil
+}
+
+func (cfg *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(cfg); err != nil {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (cfg *Config) applyEnv(env map[string]string) error {
+	strs := map[string]*string{
+		"LISTEN_ADDR":                 &cfg.ListenAddr,
+		"JWT_ALGORITHM":               &cfg.JWTAlgorithm,
+		"JWT_PRIVATE_KEY_FILE":        &cfg.JWTPrivateKeyFile,
+		"RBAC_POLICY_FILE":            &cfg.RBACPolicyFile,
+		"STORAGE_BACKEND":             &cfg.Storage,
+		"LOG_LEVEL":                   &cfg.LogLevel,
+		"TRACING_EXPORTER":            &cfg.TracingExporter,
+		"OTEL_EXPORTER_OTLP_ENDPOINT": &cfg.OTLPEndpoint,
+	}
+	for name, target := range strs {
+		if v := env[name]; v != "" {
+			*target = v
+		}
+	}
+
+	durations := map[string]*Duration{
+		"SESSION_TTL":        &cfg.SessionTTL,
+		"IMPERSONATION_TTL":  &cfg.ImpersonationTTL,
+		"IDEMPOTENCY_TTL":    &cfg.IdempotencyTTL,
+		"TRASH_RETENTION":    &cfg.TrashRetention,
+		"READ_TIMEOUT":       &cfg.ReadTimeout,
+		"WRITE_TIMEOUT":      &cfg.WriteTimeout,
+		"IDLE_TIMEOUT":       &cfg.IdleTimeout,
+		"SHUTDOWN_TIMEOUT":   &cfg.ShutdownTimeout,
+		"LOGIN_LOCKOUT_BASE": &cfg.LoginLockoutBase,
+		"LOGIN_LOCKOUT_MAX":  &cfg.LoginLockoutMax,
+		"CORS_MAX_AGE":       &cfg.CORSMaxAge,
+		"HSTS_MAX_AGE":       &cfg.HSTSMaxAge,
+		"JWT_KEY_ROTATION":   &cfg.JWTKeyRotation,
+	}
+	for name, target := range durations {
+		if v := env[name]; v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			target.Duration = d
+		}
+	}
+
+	ints := map[string]*int{
+		"MAX_HEADER_BYTES":        &cfg.MaxHeaderBytes,
+		"RATE_LIMIT_IP_BURST":     &cfg.RateLimitIPBurst,
+		"RATE_LIMIT_USER_BURST":   &cfg.RateLimitUserBurst,
+		"LOGIN_RATE_LIMIT_BURST":  &cfg.LoginRateLimitBurst,
+		"LOGIN_LOCKOUT_THRESHOLD": &cfg.LoginLockoutThreshold,
+	}
+	for name, target := range ints {
+		if v := env[name]; v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = n
+		}
+	}
+
+	floats := map[string]*float64{
+		"RATE_LIMIT_IP_RPS":    &cfg.RateLimitIPRPS,
+		"RATE_LIMIT_USER_RPS":  &cfg.RateLimitUserRPS,
+		"LOGIN_RATE_LIMIT_RPS": &cfg.LoginRateLimitRPS,
+	}
+	for name, target := range floats {
+		if v := env[name]; v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = f
+		}
+	}
+	if v := env["MAX_BODY_BYTES"]; v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid MAX_BODY_BYTES: %w", err)
+		}
+		cfg.MaxBodyBytes = n
+	}
+
+	if v := env["CORS_ORIGINS"]; v != "" {
+		cfg.CORSOrigins = splitList(v)
+	}
+	if v := env["TRUSTED_PROXIES"]; v != "" {
+		cfg.TrustedProxies = splitList(v)
+	}
+	if v := env["CORS_METHODS"]; v != "" {
+		cfg.CORSMethods = splitList(strings.ToUpper(v))
+	}
+	if v := env["CORS_ALLOW_CREDENTIALS"]; v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid CORS_ALLOW_CREDENTIALS: %w", err)
+		}
+		cfg.CORSAllowCredentials = b
+	}
+	return nil
+}
+
+// Ayarların tutarlı olup olmadığını kontrol eder
+func (cfg *Config) Validate() error {
+	var problems []string
+
+	if cfg.ListenAddr == "" {
+		problems = append(problems, "listen address is required")
+	}
+	if !contains(jwtAlgorithms, cfg.JWTAlgorithm) {
+		problems = append(problems, fmt.Sprintf("invalid JWT algorithm %q (expected one of: %s)", cfg.JWTAlgorithm, strings.Join(jwtAlgorithms, ", ")))
+	}
+	if cfg.JWTKeyRotation.Duration < 0 {
+		problems = append(problems, "jwt key rotation must not be negative")
+	}
+	durations := []struct {
+		name  string
+		value Duration
+	}{
+		{"session ttl", cfg.SessionTTL},
+		{"impersonation ttl", cfg.ImpersonationTTL},
+		{"idempotency ttl", cfg.IdempotencyTTL},
+		{"trash retention", cfg.TrashRetention},
+		{"read timeout", cfg.ReadTimeout},
+		{"write timeout", cfg.WriteTimeout},
+		{"idle timeout", cfg.IdleTimeout},
+		{"shutdown timeout", cfg.ShutdownTimeout},
+		{"login lockout base", cfg.LoginLockoutBase},
+		{"login lockout max", cfg.LoginLockoutMax},
+	}
+	for _, d := range durations {
+		if d.value.Duration <= 0 {
+			problems = append(problems, d.name+" must be positive")
+		}
+	}
+	if cfg.MaxHeaderBytes <= 0 {
+		problems = append(problems, "max header bytes must be positive")
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		problems = append(problems, "max body bytes must be positive")
+	}
+	limits := []struct {
+		name  string
+		rps   float64
+		burst int
+	}{
+		{"ip rate limit", cfg.RateLimitIPRPS, cfg.RateLimitIPBurst},
+		{"user rate limit", cfg.RateLimitUserRPS, cfg.RateLimitUserBurst},
+		{"login rate limit", cfg.LoginRateLimitRPS, cfg.LoginRateLimitBurst},
+	}
+	for _, l := range limits {
+		if l.rps < 0 {
+			problems = append(problems, l.name+" rps must not be negative")
+		} else if l.rps > 0 && l.burst < 1 {
+			problems = append(problems, l.name+" burst must be at least 1")
+		}
+	}
+	if cfg.CORSMaxAge.Duration < 0 {
+		problems = append(problems, "cors max age must not be negative")
+	}
+	if cfg.HSTSMaxAge.Duration < 0 {
+		problems = append(problems, "hsts max age must not be negative")
+	}
+	if cfg.CORSAllowCredentials && contains(cfg.CORSOrigins, "*") {
+		problems = append(problems, "CORS credentials cannot be allowed for the \"*\" origin")
+	}
+	for _, method := range cfg.CORSMethods {
+		if !contains(corsMethods, method) {
+			problems = append(problems, fmt.Sprintf("invalid CORS method %q", method))
+		}
+	}
+	for _, proxy := range cfg.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				problems = append(problems, fmt.Sprintf("invalid trusted proxy %q (expected an IP address or CIDR)", proxy))
+			}
+		}
+	}
+	if cfg.LoginLockoutThreshold < 0 {
+		problems = append(problems, "login lockout threshold must not be negative")
+	}
+	if cfg.LoginLockoutMax.Duration < cfg.LoginLockoutBase.Duration {
+		problems = append(problems, "login lockout max must not be shorter than login lockout base")
+	}
+	if !contains(storageBackends, cfg.Storage) {
+		problems = append(problems, fmt.Sprintf("unsupported storage backend %q (supported: %s)", cfg.Storage, strings.Join(storageBackends, ", ")))
+	}
+	if !contains(logLevels, cfg.LogLevel) {
+		problems = append(problems, fmt.Sprintf("invalid log level %q (expected one of: %s)", cfg.LogLevel, strings.Join(logLevels, ", ")))
+	}
+	if !contains(tracingExports, cfg.TracingExporter) {
+		problems = append(problems, fmt.Sprintf("invalid tracing exporter %q (expected one of: %s)", cfg.TracingExporter, strings.Join(tracingExports, ", ")))
+	}
+	if cfg.TracingExporter == "otlp" {
+		if u, err := url.Parse(cfg.OTLPEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("invalid OTLP endpoint %q", cfg.OTLPEndpoint))
+		}
+	}
+	for _, origin := range cfg.CORSOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			problems = append(problems, fmt.Sprintf("invalid CORS origin %q", origin))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration: " + strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var result []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Testi boş bir dizinde ve ilgili ortam değişkenleri tanımsızken çalıştırır
+func isolate(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for _, name := range append(names, "CONFIG_FILE") {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+	return dir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	dir := isolate(t, "LISTEN_ADDR", "SESSION_TTL", "LOG_LEVEL", "IDEMPOTENCY_TTL", "TRASH_RETENTION")
+	configFile := filepath.Join(dir, "config.json")
+	writeFile(t, configFile, `{"listen_addr": ":1000", "session_ttl": "1h", "log_level": "debug", "idempotency_ttl": "1h", "trash_retention": "1h"}`)
+	writeFile(t, ".env", "LISTEN_ADDR=:2000\nSESSION_TTL=2h\nIDEMPOTENCY_TTL=2h\n")
+	t.Setenv("LISTEN_ADDR", ":3000")
+	t.Setenv("IDEMPOTENCY_TTL", "3h")
+	t.Setenv("CONFIG_FILE", configFile)
+
+	cfg, err := Load([]string{"-listen", ":4000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"flag over env", cfg.ListenAddr, ":4000"},
+		{"env over .env", cfg.IdempotencyTTL.Duration, 3 * time.Hour},
+		{".env over file", cfg.SessionTTL.Duration, 2 * time.Hour},
+		{"file over default", cfg.LogLevel, "debug"},
+		{"file only", cfg.TrashRetention.Duration, time.Hour},
+		{"default", cfg.JWTAlgorithm, "EdDSA"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestLoadFlagsOnlyWhenSet(t *testing.T) {
+	isolate(t, "LOG_LEVEL", "IMPERSONATION_TTL")
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("IMPERSONATION_TTL", "10m")
+
+	// Verilmeyen bayrağın sıfır değeri ortam değişkenini ezmez; açıkça verilen bayrak ezer
+	cfg, err := Load(nil)
+	if err != nil || cfg.LogLevel != "warn" || cfg.ImpersonationTTL.Duration != 10*time.Minute {
+		t.Fatalf("env values: %+v, %v", cfg, err)
+	}
+	cfg, err = Load([]string{"-log-level", "debug"})
+	if err != nil || cfg.LogLevel != "debug" || cfg.ImpersonationTTL.Duration != 10*time.Minute {
+		t.Fatalf("explicit flag: %+v, %v", cfg, err)
+	}
+}
+
+func TestLoadExampleConfig(t *testing.T) {
+	example, err := filepath.Abs("../config.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	isolate(t)
+	if _, err := Load([]string{"-config", example}); err != nil {
+		t.Fatalf("config.example.json: %v", err)
+	}
+}
+
+func TestLoadRejectsInvalidConfiguration(t *testing.T) {
+	dir := isolate(t, "SESSION_TTL", "JWT_ALGORITHM")
+
+	unknown := filepath.Join(dir, "unknown.json")
+	writeFile(t, unknown, `{"listen": ":8081"}`)
+	if _, err := Load([]string{"-config", unknown}); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("unknown field: err = %v", err)
+	}
+
+	t.Setenv("SESSION_TTL", "bir gün")
+	if _, err := Load(nil); err == nil || !strings.Contains(err.Error(), "invalid SESSION_TTL") {
+		t.Errorf("invalid duration: err = %v", err)
+	}
+	os.Unsetenv("SESSION_TTL")
+
+	// Tüm sorunlar tek hatada listelenir
+	_, err := Load([]string{"-jwt-algorithm", "HS256", "-log-level", "loud", "-storage", "postgres", "-cors-origins", "app.example", "-session-ttl", "-1s", "-trusted-proxies", "10.0.0.0/8,proxy.local"})
+	if err == nil {
+		t.Fatal("invalid configuration accepted")
+	}
+	for _, want := range []string{"invalid JWT algorithm", "invalid log level", "unsupported storage backend", "invalid CORS origin", "session ttl must be positive", "invalid trusted proxy \"proxy.local\""} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
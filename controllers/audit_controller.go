@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Sorgu parametrelerinden denetim filtresi oluşturur.
+// Desteklenen parametreler: actor_id, action, target_type, target_id, from, to (RFC3339), limit
+func getAuditFilter(c *gin.Context) (models.AuditFilter, error) {
+	filter := models.AuditFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+	}
+
+	ints := map[string]*int{
+		"actor_id":  &filter.ActorID,
+		"target_id": &filter.TargetID,
+		"limit":     &filter.Limit,
+	}
+	for name, dst := range ints {
+		if raw := c.Query(name); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil || v < 0 {
+				return filter, errors.New("invalid " + name)
+			}
+			*dst = v
+		}
+	}
+
+	times := map[string]*time.Time{
+		"from": &filter.From,
+		"to":   &filter.To,
+	}
+	for name, dst := range times {
+		if raw := c.Query(name); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return filter, errors.New("invalid " + name)
+			}
+			*dst = t
+		}
+	}
+
+	return filter, nil
+}
+
+func GetAuditEvents(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	filter, err := getAuditFilter(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+		return
+	}
+	events, err := services.GetAuditEvents(c.Request.Context(), actor, filter)
+	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to read the audit log")
+			return
+		}
+		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to retrieve audit events")
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
+
+// Denetim kayıtlarını JSON Lines (her satırda bir kayıt) olarak indirir
+func ExportAuditEvents(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	filter, err := getAuditFilter(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+		return
+	}
+	events, err := services.GetAuditEvents(c.Request.Context(), actor, filter)
+	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to read the audit log")
+			return
+		}
+		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to retrieve audit events")
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="audit.jsonl"`)
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "application/x-ndjson")
+	encoder := json.NewEncoder(c.Writer)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return
+		}
+	}
+}
@@ -1,44 +1,70 @@
 package controllers
 
 import (
+	"math"
 	"net/http"
+	"priviatodolist/config"
+	"priviatodolist/jwtkeys"
 	"priviatodolist/middleware"
-	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
-func Login(c *gin.Context) {
+// Login, oturum süresi ve giriş kilidi ayarları cfg'den alınarak oluşturulur; token keys ile imzalanır
+func Login(cfg *config.Config, keys *jwtkeys.KeySet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		login(c, cfg, keys)
+	}
+}
+
+func login(c *gin.Context, cfg *config.Config, keys *jwtkeys.KeySet) {
 	var loginData struct {
 		Username string `json:"username"`
 		Password string `json:"password"`
 	}
 
 	if err := c.ShouldBindJSON(&loginData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		utils.HandleError(c, http.StatusBadRequest, nil, "Invalid JSON")
 		return
 	}
 
-	pass, ok := mockdb.Users[loginData.Username]
-	if !ok || pass != loginData.Password {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid username or password"})
-		return
-	}
+	// Login öncesi kullanıcı bilinmez; denetim kaydı için sadece IP ve istek kimliği taşınır
+	requestActor := models.Actor{IP: c.ClientIP(), RequestID: c.GetHeader("X-Request-ID")}
 
-	role := mockdb.UserRoles[loginData.Username]
-	if role == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User role not found"})
+	lockout := models.LoginLockout{
+		Threshold: cfg.LoginLockoutThreshold,
+		BaseDelay: cfg.LoginLockoutBase.Duration,
+		MaxDelay:  cfg.LoginLockoutMax.Duration,
+	}
+	user, err := services.Authenticate(c.Request.Context(), loginData.Username, loginData.Password, requestActor, lockout)
+	if err != nil {
+		if err.Error() == "login locked" {
+			retryAfter := services.LoginRetryAfter(loginData.Username)
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			utils.HandleError(c, http.StatusTooManyRequests, nil, "Too many failed login attempts, try again later")
+			return
+		}
+		if err.Error() == "user disabled" {
+			utils.HandleError(c, http.StatusForbidden, nil, "User is disabled")
+			return
+		}
+		utils.HandleError(c, http.StatusBadRequest, nil, "Invalid username or password")
 		return
 	}
 
-	userID := mockdb.UserIDs[loginData.Username]
-	if userID == 0 {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User ID not found"})
+	session, err := services.StartSession(c.Request.Context(), user, requestActor, c.Request.UserAgent(), cfg.SessionTTL.Duration)
+	if err != nil {
+		utils.HandleError(c, http.StatusInternalServerError, err, "Session could not be created")
 		return
 	}
-	token, err := middleware.GenerateToken(userID, loginData.Username, role)
+
+	token, err := middleware.GenerateToken(keys, user.ID, user.Username, user.Role, session.ID, session.ExpiresAt)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Token generation failed"})
+		utils.HandleError(c, http.StatusInternalServerError, err, "Token generation failed")
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"token": token})
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+func CreateCalendarToken(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	token, err := services.CreateCalendarToken(c.Request.Context(), actor)
+	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to create a calendar feed")
+			return
+		}
+		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to create calendar feed")
+		return
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	c.JSON(http.StatusCreated, models.CalendarFeed{
+		URL:   scheme + "://" + c.Request.Host + "/api/v1/calendar/" + token + ".ics",
+		Token: token,
+	})
+}
+
+func RevokeCalendarToken(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	if err := services.RevokeCalendarToken(c.Request.Context(), actor); err != nil {
+		switch {
+		case err.Error() == "forbidden":
+			utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to revoke the calendar feed")
+		case strings.Contains(err.Error(), "not found"):
+			utils.HandleError(c, http.StatusNotFound, err, "Calendar feed not found")
+		default:
+			utils.HandleError(c, http.StatusInternalServerError, err, "Failed to revoke calendar feed")
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked"})
+}
+
+// JWT gerektirmez; erişim URL'deki token ile sağlanır
+func GetCalendarFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	data, err := services.GetCalendarFeed(c.Request.Context(), token)
+	if err != nil {
+		utils.HandleError(c, http.StatusNotFound, err, "Calendar not found")
+		return
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, calendarContentType, data)
+}
+
+func ImportCalendarItems(c *gin.Context) {
+	listID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		utils.HandleError(c, http.StatusRequestEntityTooLarge, err, "Import file is too large")
+		return
+	}
+	items, problems, err := services.ImportCalendarItems(c.Request.Context(), listID, actor, data)
+	if err != nil {
+		switch err.Error() {
+		case "invalid import":
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"status": http.StatusUnprocessableEntity, "message": "Import contains invalid items", "problems": problems})
+		case "unauthorized":
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to add items to this list")
+		case "list not found":
+			utils.HandleError(c, http.StatusNotFound, err, "Todo list not found")
+		default:
+			utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, items)
+}
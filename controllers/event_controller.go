@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GET /admin/events?since=<seq>
+func GetEvents(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var since int64
+	if raw := c.Query("since"); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v < 0 {
+			utils.HandleError(c, http.StatusBadRequest, err, "Invalid since parameter")
+			return
+		}
+		since = v
+	}
+	events, err := services.GetEvents(c.Request.Context(), actor, since)
+	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to read events")
+			return
+		}
+		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to retrieve events")
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
+
+// Liste ve item durumunu event log'dan yeniden oluşturur
+func ReplayEvents(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	seq, err := services.ReplayEvents(c.Request.Context(), actor)
+	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to replay events")
+			return
+		}
+		utils.HandleError(c, http.StatusInternalServerError, err, "Event replay failed")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Projection rebuilt from event log", "last_seq": seq})
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// İçe aktarılan dosya için üst sınır
+const maxImportSize = 1 << 20
+
+var exportContentTypes = map[string]string{
+	models.ExportJSON:     "application/json; charset=utf-8",
+	models.ExportCSV:      "text/csv; charset=utf-8",
+	models.ExportMarkdown: "text/markdown; charset=utf-8",
+}
+
+func ExportTodoList(c *gin.Context) {
+	listID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	format := c.DefaultQuery("format", models.ExportJSON)
+	data, _, err := services.ExportTodoList(c.Request.Context(), listID, actor, format)
+	if err != nil {
+		switch err.Error() {
+		case "forbidden":
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to access this list")
+		case "list not found":
+			utils.HandleError(c, http.StatusNotFound, err, "Todo list not found")
+		case "unsupported format":
+			utils.HandleError(c, http.StatusBadRequest, err, "Format must be csv, md or json")
+		default:
+			utils.HandleError(c, http.StatusInternalServerError, err, "Failed to export list")
+		}
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="todolist-%d.%s"`, listID, format))
+	c.Data(http.StatusOK, exportContentTypes[format], data)
+}
+
+// Format ?format= ile verilmezse Content-Type başlığından belirlenir
+func importFormat(c *gin.Context) string {
+	if format := c.Query("format"); format != "" {
+		return format
+	}
+	switch {
+	case strings.HasPrefix(c.ContentType(), "text/csv"):
+		return models.ExportCSV
+	case strings.HasPrefix(c.ContentType(), "text/markdown"):
+		return models.ExportMarkdown
+	default:
+		return models.ExportJSON
+	}
+}
+
+func ImportTodoList(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize))
+	if err != nil {
+		utils.HandleError(c, http.StatusRequestEntityTooLarge, err, "Import file is too large")
+		return
+	}
+	list, problems, err := services.ImportTodoList(c.Request.Context(), actor, importFormat(c), c.Query("name"), data)
+	if err != nil {
+		switch err.Error() {
+		case "invalid import":
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"status": http.StatusUnprocessableEntity, "message": "Import contains invalid items", "problems": problems})
+		case "unauthorized":
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to import lists")
+		case "unsupported format":
+			utils.HandleError(c, http.StatusBadRequest, err, "Format must be csv, md or json")
+		default:
+			utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, list)
+}
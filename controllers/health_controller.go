@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/config"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GET /healthz: süreç ayakta ve istek karşılayabiliyor
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": models.HealthOK})
+}
+
+// GET /readyz: depolama, yapılandırma ve arka plan işleri hazırsa 200, değilse 503
+func Readyz(cfg *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status := services.CheckReadiness(cfg)
+		code := http.StatusOK
+		if status.Status != models.HealthOK {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, status)
+	}
+}
+
+// GET /version: git commit, derleme zamanı ve Go sürümü
+func Version(c *gin.Context) {
+	c.JSON(http.StatusOK, version.Get())
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GetTodoItemHistory(c *gin.Context) {
+	itemID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo Item ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	versions, err := services.GetItemHistory(c.Request.Context(), itemID, actor)
+	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to access this item")
+			return
+		}
+		utils.HandleError(c, http.StatusNotFound, err, "Item not found")
+		return
+	}
+	c.JSON(http.StatusOK, versions)
+}
+
+func GetTodoListHistory(c *gin.Context) {
+	listID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	history, err := services.GetListHistory(c.Request.Context(), listID, actor)
+	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to access this list")
+			return
+		}
+		utils.HandleError(c, http.StatusNotFound, err, "Todo list not found")
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// POST /items/:id/revert?version=N
+func RevertTodoItem(c *gin.Context) {
+	itemID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo Item ID")
+		return
+	}
+	version, err := strconv.Atoi(c.Query("version"))
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid version")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	item, err := services.RevertItem(c.Request.Context(), itemID, version, actor)
+	if err != nil {
+		if err.Error() == "unauthorized" {
+			utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to update this item")
+		} else if strings.Contains(err.Error(), "version") {
+			utils.HandleError(c, http.StatusNotFound, err, "Version not found")
+		} else {
+			utils.HandleError(c, http.StatusNotFound, err, "Item not found")
+		}
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
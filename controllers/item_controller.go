@@ -16,12 +16,12 @@ func GetTodoItems(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, error, "Invalid Todo List ID")
 		return
 	}
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
 	}
-	items, err := services.GetItems(listID, userID)
+	items, err := services.GetItems(c.Request.Context(), listID, actor)
 	if err != nil {
 		if err.Error() == "forbidden" {
 			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to access this list")
@@ -42,7 +42,7 @@ func AddTodoItem(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
 		return
 	}
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
@@ -52,7 +52,7 @@ func AddTodoItem(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
 		return
 	}
-	item, err := services.AddItemToList(listID, userID, &newItem)
+	item, err := services.AddItemToList(c.Request.Context(), listID, actor, &newItem)
 	if err != nil {
 		if err.Error() == "unauthorized: list does not belong to user" {
 			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to add items to this list")
@@ -60,6 +60,9 @@ func AddTodoItem(c *gin.Context) {
 		} else if strings.Contains(err.Error(), "not found") {
 			utils.HandleError(c, http.StatusNotFound, err, "Todo list not found")
 			return
+		} else if strings.HasPrefix(err.Error(), "content") {
+			utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+			return
 		}
 		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to add item")
 		return
@@ -73,7 +76,7 @@ func UpdateTodoItem(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, error, "Invalid Todo Item ID")
 		return
 	}
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
@@ -83,8 +86,16 @@ func UpdateTodoItem(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
 		return
 	}
-	item, err := services.UpdateItem(itemID, userID, &updatedItem)
+	item, err := services.UpdateItem(c.Request.Context(), itemID, actor, &updatedItem)
 	if err != nil {
+		if err.Error() == "unauthorized" {
+			utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to update this item")
+			return
+		}
+		if strings.HasPrefix(err.Error(), "content") {
+			utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+			return
+		}
 		utils.HandleError(c, http.StatusNotFound, err, "Item not found")
 		return
 	}
@@ -97,27 +108,83 @@ func DeleteTodoItem(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, error, "Invalid Todo Item ID")
 		return
 	}
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
 	}
-	if err := services.DeleteItem(itemID, userID); err != nil {
+	if err := services.DeleteItem(c.Request.Context(), itemID, actor); err != nil {
+		if err.Error() == "unauthorized" {
+			utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to delete this item")
+			return
+		}
 		utils.HandleError(c, http.StatusNotFound, err, "Item not found or already deleted")
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "Item marked as deleted"})
 }
 
+// POST /todolists/:id/:action
+// Liste üzerindeki özel işlemler; şimdilik yalnızca "items:batch" tanımlı, diğerleri 404 döner.
+func TodoListAction(c *gin.Context) {
+	switch c.Param("action") {
+	case "items:batch":
+		BatchTodoItems(c)
+	default:
+		utils.HandleError(c, http.StatusNotFound, nil, "Not found")
+	}
+}
+
+// POST /todolists/:id/items:batch
+func BatchTodoItems(c *gin.Context) {
+	listID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var req models.ItemBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
+		return
+	}
+	response, err := services.BatchItems(c.Request.Context(), listID, actor, &req)
+	if err != nil {
+		switch err.Error() {
+		case "batch validation failed":
+			c.JSON(http.StatusUnprocessableEntity, response)
+		case "unauthorized":
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to change items in this list")
+		case "list not found":
+			utils.HandleError(c, http.StatusNotFound, err, "Todo list not found")
+		default:
+			utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+		}
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
 func GetAllTodoItemsForAdmin(c *gin.Context) {
 	listID, error := getIDParam(c)
 	if error != nil {
 		utils.HandleError(c, http.StatusBadRequest, error, "Invalid Todo List ID")
 		return
 	}
-	items, err := services.GetAllItemsForAdmin(listID)
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	items, err := services.GetAllItemsForAdmin(c.Request.Context(), listID, actor)
 	if err != nil {
-		if err.Error() == "list not found" {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to access this list")
+		} else if err.Error() == "list not found" {
 			utils.HandleError(c, http.StatusNotFound, err, "Todo list not found")
 		} else {
 			utils.HandleError(c, http.StatusInternalServerError, err, "Failed to retrieve items")
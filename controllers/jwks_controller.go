@@ -0,0 +1,17 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/jwtkeys"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GET /.well-known/jwks.json: token doğrulamada kullanılabilen açık anahtarlar (JWKS)
+func JWKS(keys *jwtkeys.KeySet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Yeni anahtar yenileme sonrası kısa sürede görünsün diye önbellek süresi kısa tutulur
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, keys.JWKS())
+	}
+}
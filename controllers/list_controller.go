@@ -23,7 +23,26 @@ func getUserID(c *gin.Context) (int, bool) {
 	if !exists {
 		return 0, false
 	}
-	return userID.(int), true
+	id, ok := userID.(int)
+	if !ok {
+		return 0, false
+	}
+	return id, true
+}
+
+func getActor(c *gin.Context) (models.Actor, bool) {
+	userID, exists := getUserID(c)
+	if !exists {
+		return models.Actor{}, false
+	}
+	return models.Actor{
+		UserID:         userID,
+		Username:       c.GetString("username"),
+		Role:           c.GetString("role"),
+		ImpersonatorID: c.GetInt("impersonatorID"),
+		IP:             c.ClientIP(),
+		RequestID:      c.GetHeader("X-Request-ID"),
+	}, true
 }
 
 func CreateTodoList(c *gin.Context) {
@@ -32,12 +51,12 @@ func CreateTodoList(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
 		return
 	}
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
 	}
-	list, err := services.CreateTodoList(userID, &newList)
+	list, err := services.CreateTodoList(c.Request.Context(), actor, &newList)
 	if err != nil {
 		utils.HandleError(c, http.StatusBadRequest, err, err.Error())
 		return
@@ -46,8 +65,17 @@ func CreateTodoList(c *gin.Context) {
 }
 
 func GetTodoListsForAdmin(c *gin.Context) {
-	lists, err := services.GetAllTodoListsForAdmin()
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	lists, err := services.GetAllTodoListsForAdmin(c.Request.Context(), actor)
 	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to access all lists")
+			return
+		}
 		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to retrieve todo lists")
 		return
 	}
@@ -60,7 +88,7 @@ func UpdateTodoList(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
 		return
 	}
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
@@ -70,7 +98,7 @@ func UpdateTodoList(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
 		return
 	}
-	list, err := services.UpdateTodoList(listID, userID, &updatedList)
+	list, err := services.UpdateTodoList(c.Request.Context(), listID, actor, &updatedList)
 	if err != nil {
 		if err.Error() == "unauthorized" {
 			utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to update this list")
@@ -88,12 +116,12 @@ func DeleteTodoList(c *gin.Context) {
 		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
 		return
 	}
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
 	}
-	if err := services.DeleteTodoList(listID, userID); err != nil {
+	if err := services.DeleteTodoList(c.Request.Context(), listID, actor); err != nil {
 		if err.Error() == "unauthorized" {
 			utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to delete this list")
 		} else {
@@ -105,13 +133,17 @@ func DeleteTodoList(c *gin.Context) {
 }
 
 func GetMyTodoLists(c *gin.Context) {
-	userID, exists := getUserID(c)
+	actor, exists := getActor(c)
 	if !exists {
 		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
 		return
 	}
-	lists, err := services.GetMyTodoLists(userID)
+	lists, err := services.GetMyTodoLists(c.Request.Context(), actor)
 	if err != nil {
+		if err.Error() == "forbidden" {
+			utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to access lists")
+			return
+		}
 		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to retrieve todo lists")
 		return
 	}
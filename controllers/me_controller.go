@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func ExportMyData(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	data, err := services.ExportMyData(c.Request.Context(), actor)
+	if err != nil {
+		utils.HandleError(c, http.StatusInternalServerError, err, "Failed to export user data")
+		return
+	}
+	filename := fmt.Sprintf("priviatodolist-export-%d-%s.zip", actor.UserID, time.Now().UTC().Format("20060102"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/zip", data)
+}
+
+func DeleteMyAccount(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var req models.AccountDelete
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Password confirmation is required")
+		return
+	}
+	if err := services.DeleteMyAccount(c.Request.Context(), actor, req.Password); err != nil {
+		switch err.Error() {
+		case "forbidden":
+			utils.HandleError(c, http.StatusForbidden, err, "Impersonated sessions cannot delete the account")
+		case "invalid credentials":
+			utils.HandleError(c, http.StatusUnauthorized, err, "Invalid password")
+		case "cannot delete the last admin":
+			utils.HandleError(c, http.StatusConflict, err, err.Error())
+		default:
+			utils.HandleError(c, http.StatusInternalServerError, err, "Failed to delete account")
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Account and all its data deleted"})
+}
@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"priviatodolist/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GET /metrics: Prometheus metin formatında istek, oturum, giriş ve liste metrikleri
+func Metrics(c *gin.Context) {
+	var buf bytes.Buffer
+	metrics.Write(&buf)
+	c.Data(http.StatusOK, metrics.ContentType, buf.Bytes())
+}
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bağlantının proxy'ler tarafından kapatılmaması için gönderilen yorum satırı aralığı;
+// oturum her heartbeat'te yeniden doğrulanır (testler kısaltabilir)
+var streamHeartbeat = 25 * time.Second
+
+// GET /stream: kullanıcının erişebildiği listelerdeki değişiklikleri Server-Sent Events olarak gönderir.
+// Her mesajın id'si event sıra numarasıdır; yeniden bağlanırken Last-Event-ID başlığı (veya last_event_id
+// parametresi) ile kaçırılan event'ler alınır.
+func StreamEvents(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("last_event_id")
+	}
+	var lastSeq int64
+	if lastID != "" {
+		v, err := strconv.ParseInt(lastID, 10, 64)
+		if err != nil || v < 0 {
+			utils.HandleError(c, http.StatusBadRequest, err, "Invalid Last-Event-ID")
+			return
+		}
+		lastSeq = v
+	}
+
+	events, cancel, err := services.SubscribeStream(c.Request.Context(), actor, lastSeq)
+	if err != nil {
+		utils.HandleError(c, http.StatusServiceUnavailable, err, "Stream is not available")
+		return
+	}
+	defer cancel()
+
+	// Sunucunun yazma zaman aşımı uzun süreli stream bağlantısına uygulanmaz
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		utils.HandleError(c, http.StatusInternalServerError, err, "Stream is not available")
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprint(c.Writer, "retry: 3000\n\n")
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeStreamEvent(c, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			// Süresi dolan, iptal edilen veya devre dışı bırakılan kullanıcının bağlantısı kapatılır
+			if err := services.ValidateSession(c.GetString("sessionID"), actor.UserID, actor.ImpersonatorID); err != nil {
+				return
+			}
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+func writeStreamEvent(c *gin.Context, event models.DomainEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, models.PublicEventNames[event.Type], data); err != nil {
+		return err
+	}
+	c.Writer.Flush()
+	return nil
+}
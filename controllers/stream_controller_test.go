@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Oturumu geçersiz olan bağlantı ilk heartbeat'te kapatılır
+func TestStreamClosesWhenSessionIsInvalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	oldHeartbeat := streamHeartbeat
+	streamHeartbeat = 20 * time.Millisecond
+	defer func() { streamHeartbeat = oldHeartbeat }()
+
+	r := gin.New()
+	r.GET("/stream", func(c *gin.Context) {
+		c.Set("userID", 1)
+		c.Set("role", "user")
+		c.Set("sessionID", "revoked-session")
+	}, StreamEvents)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+
+	done := make(chan string, 1)
+	go func() {
+		body, _ := io.ReadAll(resp.Body)
+		done <- string(body)
+	}()
+	select {
+	case body := <-done:
+		if body != "retry: 3000\n\n" {
+			t.Fatalf("body = %q", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream stayed open with an invalid session")
+	}
+}
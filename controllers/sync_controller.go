@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Servis hatalarını senkronizasyon uç noktaları için HTTP yanıtına çevirir
+func handleSyncError(c *gin.Context, err error) {
+	if err.Error() == "forbidden" {
+		utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to sync lists")
+		return
+	}
+	utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+}
+
+func GetSyncChanges(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	changes, err := services.GetSyncChanges(c.Request.Context(), actor, c.Query("since"))
+	if err != nil {
+		handleSyncError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+func PushSyncChanges(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var push models.SyncPush
+	if err := c.ShouldBindJSON(&push); err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
+		return
+	}
+	result, err := services.PushSyncChanges(c.Request.Context(), actor, &push)
+	if err != nil {
+		handleSyncError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
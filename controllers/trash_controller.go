@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Servis hatalarını çöp kutusu uç noktaları için HTTP yanıtına çevirir
+func handleTrashError(c *gin.Context, err error) {
+	switch {
+	case err.Error() == "forbidden" || err.Error() == "unauthorized":
+		utils.HandleError(c, http.StatusForbidden, err, "You are not allowed to perform this action")
+	case err.Error() == "list is deleted":
+		utils.HandleError(c, http.StatusConflict, err, "Restore the list before restoring its items")
+	case strings.Contains(err.Error(), "not found"):
+		utils.HandleError(c, http.StatusNotFound, err, "Record not found in trash")
+	default:
+		utils.HandleError(c, http.StatusInternalServerError, err, "Trash operation failed")
+	}
+}
+
+func GetTrash(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	trash, err := services.GetTrash(c.Request.Context(), actor)
+	if err != nil {
+		handleTrashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, trash)
+}
+
+func RestoreTodoList(c *gin.Context) {
+	listID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	list, err := services.RestoreTodoList(c.Request.Context(), listID, actor)
+	if err != nil {
+		handleTrashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+func RestoreTodoItem(c *gin.Context) {
+	itemID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo Item ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	item, err := services.RestoreItem(c.Request.Context(), itemID, actor)
+	if err != nil {
+		handleTrashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func PurgeTodoList(c *gin.Context) {
+	listID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo List ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	if err := services.PurgeTodoList(c.Request.Context(), listID, actor); err != nil {
+		handleTrashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "List and all its items permanently deleted"})
+}
+
+func PurgeTodoItem(c *gin.Context) {
+	itemID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Todo Item ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	if err := services.PurgeItem(c.Request.Context(), itemID, actor); err != nil {
+		handleTrashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Item permanently deleted"})
+}
+
+// ?older_than=720h verilirse sadece bu süreden önce silinenler, verilmezse tüm çöp kutusu temizlenir
+func PurgeTrash(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var olderThan time.Duration
+	if raw := c.Query("older_than"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			utils.HandleError(c, http.StatusBadRequest, err, "Invalid older_than duration")
+			return
+		}
+		olderThan = d
+	}
+	result, err := services.PurgeTrash(c.Request.Context(), actor, olderThan)
+	if err != nil {
+		handleTrashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/config"
+	"priviatodolist/jwtkeys"
+	"priviatodolist/middleware"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Servis hatalarını admin kullanıcı uç noktaları için HTTP yanıtına çevirir
+func handleUserError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "forbidden":
+		utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to manage users")
+	case "user not found":
+		utils.HandleError(c, http.StatusNotFound, err, "User not found")
+	case "user disabled":
+		utils.HandleError(c, http.StatusConflict, err, "User is disabled")
+	case "username already exists":
+		utils.HandleError(c, http.StatusConflict, err, "Username already exists")
+	case "cannot disable the last admin", "cannot change the role of the last admin":
+		utils.HandleError(c, http.StatusConflict, err, "At least one active admin must remain")
+	default:
+		utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+	}
+}
+
+func GetUsersForAdmin(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	users, err := services.GetAllUsers(c.Request.Context(), actor)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, users)
+}
+
+func GetUserForAdmin(c *gin.Context) {
+	userID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid User ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	user, err := services.GetUser(c.Request.Context(), userID, actor)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func CreateUser(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var req models.UserCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
+		return
+	}
+	user, err := services.CreateUser(c.Request.Context(), actor, &req)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
+func DisableUser(c *gin.Context) {
+	setUserDisabled(c, true)
+}
+
+func EnableUser(c *gin.Context) {
+	setUserDisabled(c, false)
+}
+
+func setUserDisabled(c *gin.Context, disabled bool) {
+	userID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid User ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	user, err := services.SetUserDisabled(c.Request.Context(), userID, actor, disabled)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func ChangeUserRole(c *gin.Context) {
+	userID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid User ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var req models.UserRoleUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
+		return
+	}
+	user, err := services.ChangeUserRole(c.Request.Context(), userID, actor, req.Role)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+func ResetUserPassword(c *gin.Context) {
+	userID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid User ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var req models.PasswordReset
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
+		return
+	}
+	if err := services.ResetPassword(c.Request.Context(), userID, actor, req.Password); err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset and sessions revoked"})
+}
+
+func GetUserTodoListsForAdmin(c *gin.Context) {
+	userID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid User ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	lists, err := services.GetUserTodoLists(c.Request.Context(), userID, actor)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, lists)
+}
+
+func GetUserSessionsForAdmin(c *gin.Context) {
+	userID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid User ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	sessions, err := services.GetUserSessions(c.Request.Context(), userID, actor)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sessions)
+}
+
+// ImpersonateUser, impersonation süresi cfg'den alınarak oluşturulur; token keys ile imzalanır
+func ImpersonateUser(cfg *config.Config, keys *jwtkeys.KeySet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		impersonateUser(c, cfg, keys)
+	}
+}
+
+func impersonateUser(c *gin.Context, cfg *config.Config, keys *jwtkeys.KeySet) {
+	userID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid User ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	user, session, err := services.Impersonate(c.Request.Context(), userID, actor, cfg.ImpersonationTTL.Duration)
+	if err != nil {
+		handleUserError(c, err)
+		return
+	}
+	token, err := middleware.GenerateImpersonationToken(keys, user.ID, user.Username, user.Role, session.ID, session.ExpiresAt, actor.UserID, actor.Username)
+	if err != nil {
+		utils.HandleError(c, http.StatusInternalServerError, err, "Token generation failed")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"token": token, "expires_at": session.ExpiresAt, "user": user})
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Servis hatalarını webhook uç noktaları için HTTP yanıtına çevirir
+func handleWebhookError(c *gin.Context, err error) {
+	switch {
+	case err.Error() == "forbidden":
+		utils.HandleError(c, http.StatusForbidden, err, "You don't have permission to manage webhooks")
+	case strings.Contains(err.Error(), "not found"):
+		utils.HandleError(c, http.StatusNotFound, err, "Webhook not found")
+	default:
+		utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+	}
+}
+
+func GetMyWebhooks(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	hooks, err := services.GetMyWebhooks(c.Request.Context(), actor)
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, hooks)
+}
+
+func CreateWebhook(c *gin.Context) {
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	var req models.WebhookCreate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid request payload")
+		return
+	}
+	hook, err := services.CreateWebhook(c.Request.Context(), actor, &req)
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, hook)
+}
+
+func DeleteWebhook(c *gin.Context) {
+	webhookID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Webhook ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	if err := services.DeleteWebhook(c.Request.Context(), webhookID, actor); err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+func GetWebhookDeliveries(c *gin.Context) {
+	webhookID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Webhook ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	deliveries, err := services.GetWebhookDeliveries(c.Request.Context(), webhookID, actor)
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, deliveries)
+}
+
+func TestWebhook(c *gin.Context) {
+	webhookID, err := getIDParam(c)
+	if err != nil {
+		utils.HandleError(c, http.StatusBadRequest, err, "Invalid Webhook ID")
+		return
+	}
+	actor, exists := getActor(c)
+	if !exists {
+		utils.HandleError(c, http.StatusUnauthorized, nil, "User not authorized")
+		return
+	}
+	delivery, err := services.TestWebhook(c.Request.Context(), webhookID, actor)
+	if err != nil {
+		handleWebhookError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,331 @@
+// Package jwtkeys JWT imzalamak için asimetrik anahtar setini (RS256 veya EdDSA) yönetir.
+// Yeni token'lar her zaman güncel anahtarla imzalanır; eski anahtarlar, onlarla imzalanmış
+// token'lar geçerli kaldığı sürece doğrulama için sette tutulur ve JWKS olarak yayınlanır.
+// Yenileme açıksa bir sonraki anahtar kullanılmaya başlamadan önce JWKS'te yayınlanır; böylece
+// JWKS'i önbelleğe alan servisler yeni anahtarla imzalanmış token'ları da doğrulayabilir.
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// Desteklenen imza algoritmaları (JWT "alg" değerleri)
+const (
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+var Algorithms = []string{RS256, EdDSA}
+
+// Üretilen RSA anahtarlarının boyutu
+const rsaKeyBits = 2048
+
+// İmza anahtarı. RetireAt sıfır değilse anahtar o zamandan sonra doğrulamada kullanılmaz.
+type Key struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+	CreatedAt time.Time
+	RetireAt  time.Time
+}
+
+// Anahtar seti; keys eskiden yeniye sıralıdır ve son anahtar imza anahtarıdır.
+// next, bir sonraki yenilemede imza anahtarı olacak, şimdiden yayınlanan anahtardır.
+// fromFile ise anahtar dosyadan yüklenmiştir ve yenilenmez.
+type KeySet struct {
+	algorithm string
+	retain    time.Duration
+	fromFile  bool
+
+	mu   sync.RWMutex
+	keys []*Key
+	next *Key
+}
+
+// New verilen algoritma için yeni bir imza anahtarı üreterek set oluşturur.
+// retain, yerini yenisine bırakan anahtarın doğrulamada kalacağı süredir (en uzun token ömrü).
+func New(algorithm string, retain time.Duration) (*KeySet, error) {
+	ks := &KeySet{algorithm: algorithm, retain: retain}
+	if _, err := ks.Rotate(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// FromFile PEM (PKCS#8, RSA için PKCS#1 de olabilir) dosyasındaki özel anahtarla set oluşturur.
+// Algoritma anahtarın türünden belirlenir ve algorithm ile uyuşmalıdır.
+func FromFile(path, algorithm string, retain time.Duration) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWT private key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("JWT private key file does not contain a PEM block")
+	}
+
+	var private any
+	if block.Type == "RSA PRIVATE KEY" {
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing JWT private key: %w", err)
+	}
+
+	var key *Key
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < rsaKeyBits {
+			return nil, fmt.Errorf("RSA JWT key must be at least %d bits", rsaKeyBits)
+		}
+		key, err = newKey(RS256, k, &k.PublicKey)
+	case ed25519.PrivateKey:
+		key, err = newKey(EdDSA, k, k.Public())
+	default:
+		return nil, errors.New("JWT private key must be RSA or Ed25519")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key.Algorithm != algorithm {
+		return nil, fmt.Errorf("JWT private key is for %s but algorithm is %s", key.Algorithm, algorithm)
+	}
+	return &KeySet{algorithm: algorithm, retain: retain, fromFile: true, keys: []*Key{key}}, nil
+}
+
+// Rotate yayınlanmış bir sonraki anahtarı (yoksa yeni üretileni) imza anahtarı yapar.
+// Önceki anahtar retain süresi boyunca yalnızca doğrulama için kalır; süresi dolmuş anahtarlar
+// setten çıkarılır.
+func (ks *KeySet) Rotate() (*Key, error) {
+	ks.mu.RLock()
+	key := ks.next
+	ks.mu.RUnlock()
+	if key == nil {
+		var err error
+		if key, err = generate(ks.algorithm); err != nil {
+			return nil, err
+		}
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	now := time.Now()
+	ks.next = nil
+	if n := len(ks.keys); n > 0 {
+		ks.keys[n-1].RetireAt = now.Add(ks.retain)
+	}
+	active := ks.keys[:0]
+	for _, k := range ks.keys {
+		if k.RetireAt.After(now) {
+			active = append(active, k)
+		}
+	}
+	ks.keys = append(active, key)
+	return key, nil
+}
+
+// Bir sonraki anahtarı üretip yayınlar (zaten varsa bir şey yapmaz)
+func (ks *KeySet) prepareNext() error {
+	key, err := generate(ks.algorithm)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if ks.next == nil {
+		ks.next = key
+	}
+	return nil
+}
+
+// StartRotation anahtarı her interval'de yeniler. interval 0 ise veya anahtar dosyadan yüklendiyse
+// yenileme yapılmaz; üretilen anahtar dosyada olmadığı için yeniden başlatmada kaybolurdu.
+// Bir sonraki anahtar hemen, sonrakiler her yenilemeden sonra yayınlanır.
+// Dönen fonksiyon job'u durdurur.
+func (ks *KeySet) StartRotation(interval time.Duration) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+	if ks.fromFile {
+		slog.Info("JWT key rotation disabled for a key loaded from file", "kid", ks.SigningKey().ID)
+		return func() {}
+	}
+	if err := ks.prepareNext(); err != nil {
+		slog.Error("preparing next JWT signing key failed", "error", err)
+	}
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				key, err := ks.Rotate()
+				if err != nil {
+					slog.Error("rotating JWT signing key failed", "error", err)
+					continue
+				}
+				slog.Info("rotated JWT signing key", "kid", key.ID, "alg", key.Algorithm)
+				if err := ks.prepareNext(); err != nil {
+					slog.Error("preparing next JWT signing key failed", "error", err)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// Yeni token'ların imzalanacağı anahtar
+func (ks *KeySet) SigningKey() *Key {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	return ks.keys[len(ks.keys)-1]
+}
+
+// kid'e sahip ve doğrulamada hâlâ kullanılabilen anahtar
+func (ks *KeySet) VerificationKey(kid string) (*Key, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if ks.next != nil && ks.next.ID == kid {
+		return ks.next, true
+	}
+	now := time.Now()
+	for _, k := range ks.keys {
+		if k.ID == kid && (k.RetireAt.IsZero() || k.RetireAt.After(now)) {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+// Doğrulamada kullanılabilen anahtarlar (yeniden eskiye, varsa bir sonraki anahtar başta)
+func (ks *KeySet) VerificationKeys() []*Key {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	now := time.Now()
+	var keys []*Key
+	if ks.next != nil {
+		keys = append(keys, ks.next)
+	}
+	for i := len(ks.keys) - 1; i >= 0; i-- {
+		if k := ks.keys[i]; k.RetireAt.IsZero() || k.RetireAt.After(now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func generate(algorithm string) (*Key, error) {
+	switch algorithm {
+	case RS256:
+		private, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		return newKey(RS256, private, &private.PublicKey)
+	case EdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return newKey(EdDSA, private, public)
+	}
+	return nil, fmt.Errorf("unsupported JWT algorithm %q", algorithm)
+}
+
+func newKey(algorithm string, private crypto.Signer, public crypto.PublicKey) (*Key, error) {
+	key := &Key{Algorithm: algorithm, Private: private, Public: public, CreatedAt: time.Now()}
+	jwk := key.JWK()
+
+	// kid, RFC 7638 JWK thumbprint'idir: zorunlu alanların sıralı JSON'unun SHA-256 özeti
+	var members any
+	switch algorithm {
+	case RS256:
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	default:
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	data, err := json.Marshal(members)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	key.ID = base64.RawURLEncoding.EncodeToString(sum[:])
+	return key, nil
+}
+
+// Açık anahtarın JSON Web Key gösterimi (RFC 7517, Ed25519 için RFC 8037)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (k *Key) JWK() JWK {
+	jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Algorithm}
+	switch public := k.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// Doğrulamada kullanılabilen tüm açık anahtarlar
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, k := range ks.VerificationKeys() {
+		set.Keys = append(set.Keys, k.JWK())
+	}
+	return set
+}
@@ -0,0 +1,136 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func jwksKids(ks *KeySet) []string {
+	var kids []string
+	for _, k := range ks.JWKS().Keys {
+		kids = append(kids, k.Kid)
+	}
+	return kids
+}
+
+func writeEd25519Key(t *testing.T) string {
+	t.Helper()
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRotationPublishesNextKeyBeforeUse(t *testing.T) {
+	ks, err := New(EdDSA, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := ks.SigningKey()
+	if err := ks.prepareNext(); err != nil {
+		t.Fatal(err)
+	}
+	next := ks.next
+
+	// Bir sonraki anahtar imzada kullanılmadan önce JWKS'te ve doğrulamada yer alır
+	if kids := jwksKids(ks); len(kids) != 2 || kids[0] != next.ID || kids[1] != first.ID {
+		t.Fatalf("JWKS kids = %v", kids)
+	}
+	if ks.SigningKey().ID != first.ID {
+		t.Fatal("next key used for signing before rotation")
+	}
+
+	rotated, err := ks.Rotate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.ID != next.ID || ks.SigningKey().ID != next.ID {
+		t.Fatalf("signing kid = %s, want %s", ks.SigningKey().ID, next.ID)
+	}
+	// Eski anahtar retain süresince doğrulamada kalır
+	if _, ok := ks.VerificationKey(first.ID); !ok {
+		t.Fatal("previous key dropped before its retain period")
+	}
+	if kids := jwksKids(ks); len(kids) != 2 || kids[0] != next.ID || kids[1] != first.ID {
+		t.Fatalf("JWKS kids after rotation = %v", kids)
+	}
+}
+
+func TestRetiredKeysLeaveTheSet(t *testing.T) {
+	ks, err := New(RS256, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := ks.SigningKey()
+	if _, err := ks.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ks.VerificationKey(first.ID); ok {
+		t.Fatal("retired key still verifies tokens")
+	}
+	if kids := jwksKids(ks); len(kids) != 1 || kids[0] == first.ID {
+		t.Fatalf("JWKS kids = %v", kids)
+	}
+}
+
+func TestKeyFromFileIsNotRotated(t *testing.T) {
+	path := writeEd25519Key(t)
+	ks, err := FromFile(path, EdDSA, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid := ks.SigningKey().ID
+
+	// kid anahtarın thumbprint'idir; aynı dosya aynı kid'i verir
+	again, _ := FromFile(path, EdDSA, time.Hour)
+	if again.SigningKey().ID != kid {
+		t.Fatal("kid is not stable for the same key")
+	}
+
+	stop := ks.StartRotation(10 * time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	stop()
+	if ks.SigningKey().ID != kid {
+		t.Fatal("key loaded from file was rotated")
+	}
+	if kids := jwksKids(ks); len(kids) != 1 || kids[0] != kid {
+		t.Fatalf("JWKS kids = %v", kids)
+	}
+
+	if _, err := FromFile(path, RS256, time.Hour); err == nil {
+		t.Fatal("Ed25519 key accepted for RS256")
+	}
+}
+
+func TestGeneratedKeysAreRotated(t *testing.T) {
+	ks, err := New(EdDSA, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := ks.SigningKey().ID
+	stop := ks.StartRotation(10 * time.Millisecond)
+	defer stop()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for ks.SigningKey().ID == first {
+		if time.Now().After(deadline) {
+			t.Fatal("signing key was not rotated")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
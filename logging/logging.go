@@ -0,0 +1,123 @@
+// Package logging uygulama genelinde log/slog ile JSON log üretir.
+// Token, parola, secret ve Authorization gibi alanlar yazılmadan önce maskelenir.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Maskelenmiş değerlerin yerine yazılan metin
+const Redacted = "[REDACTED]"
+
+// Anahtarında bu parçalardan biri geçen alanlar tamamen maskelenir
+var sensitiveKeys = []string{"token", "password", "secret", "authorization", "cookie", "api_key"}
+
+// Serbest metin içindeki JWT'ler ve Bearer değerleri
+var sensitiveValues = []*regexp.Regexp{
+	regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	regexp.MustCompile(`(?i)bearer\s+\S+`),
+}
+
+// Setup varsayılan logger'ı stdout'a verilen seviyede JSON yazacak şekilde ayarlar.
+// Standart log paketi de bu logger'a yönlenir.
+func Setup(level string) {
+	slog.SetDefault(New(os.Stdout, level))
+}
+
+// New maskeleme yapan bir JSON logger döner; bilinmeyen seviye info sayılır
+func New(w io.Writer, level string) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       ParseLevel(level),
+		ReplaceAttr: redactAttr,
+	}))
+}
+
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if isSensitiveKey(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, RedactString(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, RedactString(err.Error()))
+		}
+	}
+	return a
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range sensitiveKeys {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Metin içindeki token benzeri değerleri maskeler
+func RedactString(s string) string {
+	for _, re := range sensitiveValues {
+		s = re.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// RedactPath isteğin yolunu gizli parçalar olmadan döner: takvim akışı token'ı ve
+// access_token gibi sorgu parametreleri maskelenir.
+func RedactPath(u *url.URL) string {
+	path := u.Path
+	if rest, found := strings.CutPrefix(path, "/api/v1/calendar/"); found && rest != "" {
+		path = "/api/v1/calendar/" + Redacted
+	}
+	if u.RawQuery == "" {
+		return path
+	}
+	query := u.Query()
+	for key := range query {
+		if isSensitiveKey(key) {
+			query[key] = []string{Redacted}
+		}
+	}
+	return path + "?" + query.Encode()
+}
+
+type contextKey struct{}
+
+// İsteğe özel logger'ı context'e ekler
+func WithContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// WithAttrs context'teki logger'a alan ekleyerek yeni bir context döner
+func WithAttrs(ctx context.Context, args ...any) context.Context {
+	return WithContext(ctx, FromContext(ctx).With(args...))
+}
+
+// Context'teki istek logger'ı; yoksa varsayılan logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -1,8 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"priviatodolist/config"
 	"priviatodolist/docs"
+	"priviatodolist/jwtkeys"
+	"priviatodolist/logging"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
 	"priviatodolist/routes"
+	"priviatodolist/services"
+	"priviatodolist/tracing"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 // @title           Privia Todo List API
@@ -27,7 +44,115 @@ import (
 // @type apiKey
 
 func main() {
+	// Ayarlar varsayılanlar, yapılandırma dosyası, .env, ortam değişkenleri ve bayraklardan okunur
+	// Yapılandırma okunana kadar loglar info seviyesinde yazılır
+	logging.Setup("info")
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	logging.Setup(cfg.LogLevel)
+	if cfg.LogLevel != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
 	docs.SwaggerInfo.BasePath = "/api/v1"
-	r := routes.SetupRouter()
-	r.Run(":8081")
+
+	// Rol -> izin eşlemesi dosyadan yüklenebilir, yoksa varsayılanlar kullanılır
+	if cfg.RBACPolicyFile != "" {
+		if err := policy.LoadFile(cfg.RBACPolicyFile); err != nil {
+			slog.Error("loading RBAC policy failed", "file", cfg.RBACPolicyFile, "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Başlangıç verisi event log'a aktarılır; liste ve item durumu bundan sonra event'lerden oluşur
+	if err := repositories.BootstrapEventLog(); err != nil {
+		slog.Error("bootstrapping event log failed", "error", err)
+		os.Exit(1)
+	}
+
+	// Span'lar TRACING_EXPORTER ayarına göre stdout'a veya OTLP toplayıcısına gönderilir
+	stopTracing := tracing.Setup(cfg.TracingExporter, cfg.OTLPEndpoint, "priviatodolist")
+
+	// JWT imza anahtarları; eski anahtarlar en uzun token ömrü boyunca doğrulamada kalır
+	keys, err := loadJWTKeys(cfg)
+	if err != nil {
+		slog.Error("loading JWT signing keys failed", "error", err)
+		os.Exit(1)
+	}
+	stopKeyRotation := keys.StartRotation(cfg.JWTKeyRotation.Duration)
+
+	// Arka plan işleri; kapanışta durdurulurlar
+	// Çöp kutusundaki kayıtlar retention süresi dolunca kalıcı olarak silinir (varsayılan 30 gün)
+	stopTrashRetention := services.StartTrashRetention(cfg.TrashRetention.Duration, time.Hour)
+
+	// Liste ve item event'leri kayıtlı webhook'lara imzalı olarak gönderilir
+	stopWebhooks := services.StartWebhookDispatcher()
+
+	// Liste ve item event'leri /api/v1/stream üzerinden bağlı istemcilere gönderilir
+	stopStreams := services.StartStreamBroker()
+
+	srv := &http.Server{
+		Addr:              cfg.ListenAddr,
+		Handler:           routes.SetupRouter(cfg, keys),
+		ReadTimeout:       cfg.ReadTimeout.Duration,
+		ReadHeaderTimeout: cfg.ReadTimeout.Duration,
+		WriteTimeout:      cfg.WriteTimeout.Duration,
+		IdleTimeout:       cfg.IdleTimeout.Duration,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("listening", "addr", cfg.ListenAddr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	ctx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining requests")
+	case err := <-serverErr:
+		slog.Error("server error", "error", err)
+		exitCode = 1
+	}
+	// İkinci sinyal varsayılan davranışa döner ve süreci hemen sonlandırır
+	stopSignals()
+
+	// Açık stream bağlantıları kapatılır; aksi halde zaman aşımına kadar isteklerin bitmesi beklenirdi
+	stopStreams()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout.Duration)
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("graceful shutdown failed", "error", err)
+		exitCode = 1
+	}
+	cancel()
+
+	stopTrashRetention()
+	stopKeyRotation()
+	stopWebhooks()
+	stopTracing()
+	if err := repositories.Close(); err != nil {
+		slog.Error("closing storage failed", "error", err)
+		exitCode = 1
+	}
+
+	slog.Info("server stopped")
+	os.Exit(exitCode)
+}
+
+// Anahtar dosyası verildiyse imza anahtarı oradan okunur, yoksa başlangıçta yeni bir anahtar üretilir
+func loadJWTKeys(cfg *config.Config) (*jwtkeys.KeySet, error) {
+	retain := max(cfg.SessionTTL.Duration, cfg.ImpersonationTTL.Duration)
+	if cfg.JWTPrivateKeyFile != "" {
+		return jwtkeys.FromFile(cfg.JWTPrivateKeyFile, cfg.JWTAlgorithm, retain)
+	}
+	return jwtkeys.New(cfg.JWTAlgorithm, retain)
 }
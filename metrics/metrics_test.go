@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func output(t *testing.T) string {
+	t.Helper()
+	var buf bytes.Buffer
+	Write(&buf)
+	return buf.String()
+}
+
+func TestCounterAndGaugeFormat(t *testing.T) {
+	c := NewCounterVec("test_events_total", "Events by kind.\nSecond line.", "kind")
+	c.Inc("a")
+	c.Add(2.5, "a")
+	c.Inc(`tırnak "b"`)
+	NewGaugeVecFunc("test_items", "Items by state.", func() []Sample {
+		return []Sample{{LabelValues: []string{"open"}, Value: 3}, {LabelValues: []string{"done"}, Value: 1}}
+	}, "state")
+	NewGaugeFunc("test_ratio", "Unlabelled gauge.", func() float64 { return 0.5 })
+
+	out := output(t)
+	for _, want := range []string{
+		"# HELP test_events_total Events by kind.\\nSecond line.\n# TYPE test_events_total counter\n",
+		`test_events_total{kind="a"} 3.5` + "\n",
+		`test_events_total{kind="tırnak \"b\""} 1` + "\n",
+		"# TYPE test_items gauge\n" + `test_items{state="done"} 1` + "\n" + `test_items{state="open"} 3` + "\n",
+		"test_ratio 0.5\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestHistogramBucketsAreCumulative(t *testing.T) {
+	h := NewHistogramVec("test_duration_seconds", "Durations.", []float64{0.1, 1}, "route")
+	for _, v := range []float64{0.05, 0.5, 0.5, 3} {
+		h.Observe(v, "/x")
+	}
+
+	out := output(t)
+	for _, want := range []string{
+		`test_duration_seconds_bucket{route="/x",le="0.1"} 1`,
+		`test_duration_seconds_bucket{route="/x",le="1"} 3`,
+		`test_duration_seconds_bucket{route="/x",le="+Inf"} 4`,
+		`test_duration_seconds_sum{route="/x"} 4.05`,
+		`test_duration_seconds_count{route="/x"} 4`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+}
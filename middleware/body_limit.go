@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net/http"
+	"priviatodolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BodyLimit middleware'ı limit bayttan büyük istek gövdelerini reddeder.
+// Content-Length bilinen isteklere hemen 413 döner; diğerlerinde okuma limitte hata verir.
+func BodyLimit(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			utils.HandleError(c, http.StatusRequestEntityTooLarge, nil, "Request body too large")
+			c.Abort()
+			return
+		}
+		if c.Request.Body != nil {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"priviatodolist/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tarayıcının gönderebileceği ve okuyabileceği uygulama başlıkları
+var (
+	corsAllowedHeaders = []string{"Authorization", "Content-Type", "Idempotency-Key", RequestIDHeader, "traceparent"}
+	corsExposedHeaders = []string{RequestIDHeader, "Idempotent-Replayed", "Retry-After", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Content-Disposition"}
+)
+
+// CORS, origins listesindeki kaynaklardan gelen tarayıcı isteklerine izin verir ("*" hepsi demektir).
+// Preflight (OPTIONS) istekleri burada 204 ile yanıtlanır ve maxAge süresince önbellekte tutulabilir.
+// İzin verilmeyen origin'ler için CORS başlığı eklenmez; preflight isteği 403 alır.
+func CORS(origins, methods []string, allowCredentials bool, maxAge time.Duration) gin.HandlerFunc {
+	allowAll := false
+	allowed := map[string]bool{}
+	for _, origin := range origins {
+		if origin == "*" {
+			allowAll = true
+		}
+		allowed[strings.TrimSuffix(origin, "/")] = true
+	}
+	allowMethods := strings.Join(methods, ", ")
+	allowHeaders := strings.Join(corsAllowedHeaders, ", ")
+	exposeHeaders := strings.Join(corsExposedHeaders, ", ")
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+		// Yanıt origin'e göre değiştiği için önbellekler ayrı tutmalıdır
+		c.Writer.Header().Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !allowAll && !allowed[origin] {
+			if preflight {
+				utils.HandleError(c, http.StatusForbidden, nil, "Origin not allowed")
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		// Kimlik bilgisi gönderilecekse "*" kullanılamaz, origin aynen yazılır
+		if allowAll && !allowCredentials {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if allowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			c.Header("Access-Control-Expose-Headers", exposeHeaders)
+			c.Next()
+			return
+		}
+
+		if !containsFold(methods, c.GetHeader("Access-Control-Request-Method")) {
+			utils.HandleError(c, http.StatusForbidden, nil, "Method not allowed by CORS policy")
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Method")
+		c.Writer.Header().Add("Vary", "Access-Control-Request-Headers")
+		c.Header("Access-Control-Allow-Methods", allowMethods)
+		c.Header("Access-Control-Allow-Headers", allowHeaders)
+		if maxAge > 0 {
+			c.Header("Access-Control-Max-Age", strconv.Itoa(int(maxAge.Seconds())))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,25 +1,88 @@
 package middleware
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
+	"priviatodolist/logging"
+	"priviatodolist/tracing"
+	"priviatodolist/utils"
+	"runtime/debug"
+	"strings"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 )
 
+// GlobalErrorHandler handler'larda ve kendisinden sonraki middleware'larda oluşan panic'leri yakalar.
+// Stack, istek logger'ı ile (request ID ve trace ID dahil) yazılır ve standart hata gövdesiyle 500 döner.
+// Yanıt yazılmaya başlandıysa ikinci bir yanıt yazılmaz, yalnızca istek sonlandırılır.
+// RequestID, Tracing, RequestLogger ve Metrics'teki panic'leri de kapsaması için router'da ilk middleware olmalıdır;
+// bu middleware'lar panic'li istekleri responseStatus ile 500 olarak kaydeder.
 func GlobalErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("Panic occurred: %v", r)
+			r := recover()
+			if r == nil {
+				return
+			}
+			// net/http'nin bağlantıyı sessizce kapatma isteği aynen iletilir
+			if r == http.ErrAbortHandler {
+				panic(r)
+			}
+
+			ctx := c.Request.Context()
+			logger := logging.FromContext(ctx)
+			if span := tracing.FromContext(ctx); span != nil {
+				span.SetError(fmt.Errorf("panic: %v", r))
+			}
 
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error":   "Internal server error",
-					"message": "Something went wrong. Please try again later.",
-				})
+			// İstemci bağlantıyı kapattıysa yazılacak bir yanıt yoktur
+			if brokenConnection(r) {
+				logger.Warn("client connection closed", "error", r)
+				c.Abort()
+				return
 			}
+
+			logger.Error("panic recovered", "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, utils.ErrorResponse{
+				Status:    http.StatusInternalServerError,
+				Message:   "Internal server error",
+				RequestID: c.GetString("requestID"),
+			})
 		}()
 
 		c.Next()
 	}
 }
+
+// İsteğin son durum kodu. İstek panic ile bittiyse (completed false) yanıtı GlobalErrorHandler daha sonra
+// yazacağı için henüz yazılmamış yanıt 500 sayılır.
+func responseStatus(c *gin.Context, completed bool) int {
+	if !completed && !c.Writer.Written() {
+		return http.StatusInternalServerError
+	}
+	return c.Writer.Status()
+}
+
+func brokenConnection(r any) bool {
+	err, ok := r.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if errors.As(opErr, &syscallErr) {
+		return errors.Is(syscallErr.Err, syscall.EPIPE) || errors.Is(syscallErr.Err, syscall.ECONNRESET)
+	}
+	return strings.Contains(strings.ToLower(opErr.Error()), "broken pipe")
+}
@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"priviatodolist/logging"
+	"priviatodolist/utils"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handler'ı RequestID ve GlobalErrorHandler arkasında çalıştırır; loglar dönen tampona yazılır
+func serveWithRecovery(t *testing.T, handler gin.HandlerFunc) (*httptest.ResponseRecorder, *bytes.Buffer) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&logs, "debug"))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	r := gin.New()
+	r.Use(RequestID(), GlobalErrorHandler())
+	r.GET("/", handler)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(RequestIDHeader, "req-panic")
+	r.ServeHTTP(w, req)
+	return w, &logs
+}
+
+func TestPanicReturnsErrorResponse(t *testing.T) {
+	w, logs := serveWithRecovery(t, func(c *gin.Context) { panic("boom") })
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	var body utils.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %s", w.Body)
+	}
+	if body.Message != "Internal server error" || body.RequestID != "req-panic" {
+		t.Fatalf("body = %+v", body)
+	}
+	if strings.Contains(w.Body.String(), "boom") {
+		t.Fatal("panic value leaked to the client")
+	}
+	out := logs.String()
+	if !strings.Contains(out, `"msg":"panic recovered"`) || !strings.Contains(out, `"panic":"boom"`) || !strings.Contains(out, "global_error_handler_test.go") {
+		t.Fatalf("log = %s", out)
+	}
+}
+
+func TestPanicAfterHeadersSentKeepsResponse(t *testing.T) {
+	w, logs := serveWithRecovery(t, func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain")
+		c.Status(http.StatusOK)
+		c.Writer.WriteString("partial")
+		c.Writer.Flush()
+		panic("late boom")
+	})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want the already sent 200", w.Code)
+	}
+	if w.Body.String() != "partial" {
+		t.Fatalf("body = %q, want only the partial write", w.Body)
+	}
+	if w.Header().Get("Content-Type") != "text/plain" {
+		t.Fatalf("Content-Type = %q", w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(logs.String(), `"panic":"late boom"`) {
+		t.Fatalf("panic not logged: %s", logs)
+	}
+}
+
+func TestBrokenConnectionPanicIsNotAnError(t *testing.T) {
+	err := &net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)}
+	w, logs := serveWithRecovery(t, func(c *gin.Context) { panic(err) })
+
+	if w.Body.Len() != 0 {
+		t.Fatalf("body written to closed connection: %s", w.Body)
+	}
+	out := logs.String()
+	if !strings.Contains(out, `"level":"WARN"`) || strings.Contains(out, "panic recovered") {
+		t.Fatalf("log = %s", out)
+	}
+}
+
+func TestAbortHandlerPanicIsPropagated(t *testing.T) {
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Fatalf("recovered %v, want http.ErrAbortHandler", r)
+		}
+	}()
+	serveWithRecovery(t, func(c *gin.Context) { panic(http.ErrAbortHandler) })
+	t.Fatal("ErrAbortHandler was swallowed")
+}
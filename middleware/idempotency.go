@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"priviatodolist/services"
+	"priviatodolist/utils"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Yanıt gövdesini istemciye yazarken bir kopyasını da saklar
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency-Key başlığıyla tekrar gönderilebilen, durum değiştiren yöntemler
+var idempotentMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// Handler'ın eklediği veya değiştirdiği başlıklar; önceki middleware'ların başlıkları tekrarda yeniden eklenir
+func addedHeaders(before, after http.Header) http.Header {
+	added := http.Header{}
+	for k, v := range after {
+		if !slices.Equal(before[k], v) {
+			added[k] = slices.Clone(v)
+		}
+	}
+	return added
+}
+
+// Idempotency middleware'ı Idempotency-Key başlığı olan POST, PUT ve DELETE isteklerinin yanıtını
+// (durum kodu, gövde ve handler'ın eklediği Content-Type, Content-Disposition gibi başlıklar) saklar.
+// Aynı anahtarla gelen tekrarlarda istek yeniden işlenmez, saklanan yanıt döner.
+// Anahtar farklı bir istekle tekrar kullanılırsa 422, ilk istek hâlâ işleniyorsa 409 döner.
+// JWT middleware'ından sonra kullanılmalıdır; anahtarlar kullanıcı bazındadır ve ttl süresince saklanır.
+func Idempotency(ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		method := c.Request.Method
+		if key == "" || !idempotentMethods[method] {
+			c.Next()
+			return
+		}
+		userID := c.GetInt("userID")
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			// Content-Length bilinmeyen büyük gövdeler BodyLimit'in okuma sınırına burada takılır
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				utils.HandleError(c, http.StatusRequestEntityTooLarge, nil, "Request body too large")
+			} else {
+				utils.HandleError(c, http.StatusBadRequest, nil, "Invalid request body")
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Aynı anahtar yalnızca aynı uç noktaya aynı gövdeyle tekrar gönderilebilir
+		sum := sha256.Sum256(body)
+		fingerprint := method + " " + c.Request.URL.RequestURI() + " " + hex.EncodeToString(sum[:])
+
+		record, err := services.BeginIdempotentRequest(userID, key, fingerprint, ttl)
+		if err != nil {
+			switch err.Error() {
+			case "idempotency key reused":
+				utils.HandleError(c, http.StatusUnprocessableEntity, nil, "Idempotency-Key was already used with a different request")
+			case "idempotency key in progress":
+				utils.HandleError(c, http.StatusConflict, nil, "A request with this Idempotency-Key is still being processed")
+			default:
+				utils.HandleError(c, http.StatusBadRequest, err, err.Error())
+			}
+			c.Abort()
+			return
+		}
+		if record != nil {
+			for k, v := range record.Header {
+				c.Writer.Header()[k] = v
+			}
+			c.Header("Idempotent-Replayed", "true")
+			c.Status(record.StatusCode)
+			c.Writer.Write(record.Body)
+			c.Abort()
+			return
+		}
+
+		// Handler panic'lerse anahtar "işleniyor" durumunda kalmasın
+		defer func() {
+			if r := recover(); r != nil {
+				services.ReleaseIdempotentRequest(userID, key)
+				panic(r)
+			}
+		}()
+
+		headerBefore := c.Writer.Header().Clone()
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Sunucu hatalarında yanıt saklanmaz; istemci aynı anahtarla tekrar deneyebilir
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			services.ReleaseIdempotentRequest(userID, key)
+			return
+		}
+		services.CompleteIdempotentRequest(userID, key, status, addedHeaders(headerBefore, recorder.Header()), recorder.body.Bytes())
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+	"priviatodolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DenyImpersonation middleware'ı impersonation token'ı ile gelen istekleri reddeder.
+// Admin rotalarında kullanılarak impersonation oturumlarının yetki yükseltmesi engellenir.
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, impersonated := c.Get("impersonatorID"); impersonated {
+			utils.HandleError(c, http.StatusForbidden, nil, "Impersonated sessions cannot access admin routes")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,41 +1,28 @@
 package middleware
 
 import (
-	"log"
+	"errors"
+	"log/slog"
 	"net/http"
-	"os"
+	"priviatodolist/jwtkeys"
+	"priviatodolist/logging"
+	"priviatodolist/services"
+	"priviatodolist/utils"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/joho/godotenv"
 )
 
-var jwtSecret []byte
-
-func init() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
-	}
-
-	secret := os.Getenv("SECRET_KEY")
-	if secret == "" {
-		log.Fatal("SECRET_KEY is not set in the environment")
-	}
-
-	jwtSecret = []byte(secret)
-	log.Println("Secret key loaded successfully")
-}
-
-// JWT Middleware
-func JWTAuthMiddleware() gin.HandlerFunc {
+// JWT Middleware; token'lar başlığındaki "kid" ile setten seçilen açık anahtarla doğrulanır
+func JWTAuthMiddleware(keys *jwtkeys.KeySet) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		logger := logging.FromContext(c.Request.Context())
 		tokenString := c.GetHeader("Authorization")
 		if tokenString == "" {
-			log.Println("No token provided")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "No token provided"})
+			logger.Debug("no token provided")
+			utils.HandleError(c, http.StatusUnauthorized, nil, "No token provided")
 			c.Abort()
 			return
 		}
@@ -43,69 +30,127 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		// "Bearer " varsa temizle
 		tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 
-		log.Println("Parsing token:", tokenString)
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				log.Println("Invalid signing method")
+			kid, _ := token.Header["kid"].(string)
+			key, ok := keys.VerificationKey(kid)
+			if !ok {
+				return nil, errors.New("unknown signing key")
+			}
+			// Anahtarın algoritması dışındaki imzalar (ör. açık anahtarla HS256) reddedilir
+			if token.Method.Alg() != key.Algorithm {
+				logger.Debug("invalid signing method", "alg", token.Method.Alg(), "kid", kid)
 				return nil, http.ErrNotSupported
 			}
-			return jwtSecret, nil
-		})
+			return key.Public, nil
+		}, jwt.WithValidMethods(jwtkeys.Algorithms))
 
 		if err != nil || !token.Valid {
-			log.Println("Invalid token:", err)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			logger.Debug("invalid token", "error", err)
+			utils.HandleError(c, http.StatusUnauthorized, nil, "Invalid token")
 			c.Abort()
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			log.Println("Invalid token claims")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			logger.Debug("invalid token claims")
+			utils.HandleError(c, http.StatusUnauthorized, nil, "Invalid token claims")
 			c.Abort()
 			return
 		}
 
-		log.Println("Token claims parsed successfully")
-
 		userIDFloat, ok := claims["userID"].(float64)
 		if !ok {
-			log.Println("userID not found or not a number")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid userID in token"})
+			logger.Debug("userID claim missing or not a number")
+			utils.HandleError(c, http.StatusUnauthorized, nil, "Invalid userID in token")
 			c.Abort()
 			return
 		}
 
-		// Token'den alınan "userID" ve "role" değerlerini context'e ekle
+		// Token bir oturuma bağlı olmalı; iptal edilmiş oturumlar ve devre dışı kullanıcılar reddedilir
 		userID := int(userIDFloat)
+		sessionID, _ := claims["sid"].(string)
+
+		// "act" claim'i varsa token bir admin tarafından bu kullanıcı adına alınmıştır
+		impersonatorID := 0
+		if act, ok := claims["act"].(map[string]interface{}); ok {
+			actorIDFloat, ok := act["userID"].(float64)
+			if !ok {
+				utils.HandleError(c, http.StatusUnauthorized, nil, "Invalid act claim in token")
+				c.Abort()
+				return
+			}
+			impersonatorID = int(actorIDFloat)
+		}
+
+		if err := services.ValidateSession(sessionID, userID, impersonatorID); err != nil {
+			logger.Info("session rejected", "user_id", userID, "error", err)
+			utils.HandleError(c, http.StatusUnauthorized, nil, "Session expired or revoked")
+			c.Abort()
+			return
+		}
+
+		// Token'den alınan "userID" ve "role" değerlerini context'e ekle
 		c.Set("userID", userID)
+		c.Set("sessionID", sessionID)
+		if impersonatorID != 0 {
+			c.Set("impersonatorID", impersonatorID)
+		}
 		c.Set("username", claims["username"])
 		c.Set("role", claims["role"])
 
-		log.Println("UserID set to context:", userID)
+		// İsteğin logger'ı bundan sonra kullanıcı bilgisini de taşır
+		attrs := []any{"user_id", userID}
+		if impersonatorID != 0 {
+			attrs = append(attrs, "impersonator_id", impersonatorID)
+		}
+		c.Request = c.Request.WithContext(logging.WithAttrs(c.Request.Context(), attrs...))
 
 		c.Next()
 	}
 }
 
-func GenerateToken(userID int, username, role string) (string, error) {
-	log.Println("Generating token for userID:", userID, "username:", username)
+func GenerateToken(keys *jwtkeys.KeySet, userID int, username, role, sessionID string, expiresAt time.Time) (string, error) {
+	slog.Debug("generating token", "user_id", userID)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	return signToken(keys, jwt.MapClaims{
 		"userID":   userID,
 		"username": username,
 		"role":     role,
-		"exp":      time.Now().Add(72 * time.Hour).Unix(),
+		"sid":      sessionID,
+		"exp":      expiresAt.Unix(),
 	})
+}
+
+// GenerateImpersonationToken, actorID'li admin'in userID adına işlem yapmasını sağlayan token üretir.
+// Admin bilgisi "act" claim'inde taşınır.
+func GenerateImpersonationToken(keys *jwtkeys.KeySet, userID int, username, role, sessionID string, expiresAt time.Time, actorID int, actorUsername string) (string, error) {
+	slog.Debug("generating impersonation token", "user_id", userID, "impersonator_id", actorID)
+
+	return signToken(keys, jwt.MapClaims{
+		"userID":   userID,
+		"username": username,
+		"role":     role,
+		"sid":      sessionID,
+		"exp":      expiresAt.Unix(),
+		"act": map[string]interface{}{
+			"userID":   actorID,
+			"username": actorUsername,
+		},
+	})
+}
+
+// Token setin güncel anahtarıyla imzalanır; doğrulayıcılar anahtarı "kid" başlığından bulur
+func signToken(keys *jwtkeys.KeySet, claims jwt.MapClaims) (string, error) {
+	key := keys.SigningKey()
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
 
 	// Token'ı imzala
-	signedToken, err := token.SignedString(jwtSecret)
+	signedToken, err := token.SignedString(key.Private)
 	if err != nil {
-		log.Println("Error signing token:", err)
+		slog.Error("signing token failed", "error", err)
 		return "", err
 	}
-
-	log.Println("Token generated successfully")
 	return signedToken, nil
 }
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"priviatodolist/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	httpRequests = metrics.NewCounterVec("http_requests_total", "HTTP requests by method, route and status.", "method", "route", "status")
+	httpDuration = metrics.NewHistogramVec("http_request_duration_seconds", "HTTP request latency by method, route and status.", metrics.DefaultBuckets, "method", "route", "status")
+)
+
+// Her isteğin sayısını ve süresini rota şablonu ve durum koduna göre kaydeder.
+// Eşleşmeyen yollar etiket sayısını sınırlamak için "unmatched" olarak toplanır.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		// Panic'li istekler de 500 olarak sayılır; panic'i GlobalErrorHandler yakalar
+		completed := false
+		defer func() {
+			route := c.FullPath()
+			if route == "" {
+				route = "unmatched"
+			}
+			status := strconv.Itoa(responseStatus(c, completed))
+			httpRequests.Inc(c.Request.Method, route, status)
+			httpDuration.Observe(time.Since(start).Seconds(), c.Request.Method, route, status)
+		}()
+
+		c.Next()
+		completed = true
+	}
+}
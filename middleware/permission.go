@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+	"priviatodolist/policy"
+	"priviatodolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission middleware'ı, kullanıcının rolü verilen izinlerin hepsine sahip değilse isteği reddeder.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		roleName, _ := role.(string)
+
+		for _, p := range permissions {
+			if !policy.HasPermission(roleName, p) {
+				utils.HandleError(c, http.StatusForbidden, nil, "Missing permission: "+p)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"priviatodolist/utils"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Boşta kalıp dolan kovalar bu aralıkla temizlenir
+const rateLimitSweepInterval = time.Minute
+
+// Anahtar başına token bucket: kova burst kadar token alır ve saniyede rps token dolar
+type rateLimiter struct {
+	rps       float64
+	burst     float64
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Bir token almayı dener; remaining kalan tam token sayısı, wait bir sonraki token için beklenecek süre,
+// reset kovanın tamamen dolması için gereken süredir.
+func (l *rateLimiter) take(key string, now time.Time) (allowed bool, remaining int, wait, reset time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > rateLimitSweepInterval {
+		l.sweep(now)
+	}
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rps)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		allowed = true
+	} else {
+		wait = l.duration(1 - b.tokens)
+	}
+	return allowed, int(b.tokens), wait, l.duration(l.burst - b.tokens)
+}
+
+func (l *rateLimiter) duration(tokens float64) time.Duration {
+	return time.Duration(tokens / l.rps * float64(time.Second))
+}
+
+// Dolmuş kovalar yeniden oluşturulduklarında aynı durumda olacakları için silinir
+func (l *rateLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rps >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// RateLimit, key'in döndüğü değer başına (ör. IP veya kullanıcı) token bucket sınırı uygular.
+// Yanıtlara RateLimit-Limit/Remaining/Reset başlıkları eklenir; sınır aşılırsa 429 ve Retry-After döner.
+// rps 0 ise sınır uygulanmaz; key boş dönerse istek sınırlanmaz.
+func RateLimit(rps float64, burst int, key func(c *gin.Context) string) gin.HandlerFunc {
+	if rps <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	limiter := &rateLimiter{rps: rps, burst: float64(burst), buckets: map[string]*tokenBucket{}}
+
+	return func(c *gin.Context) {
+		k := key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+
+		allowed, remaining, wait, reset := limiter.take(k, time.Now())
+		c.Header("RateLimit-Limit", strconv.Itoa(burst))
+		c.Header("RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(wait)))
+			utils.HandleError(c, http.StatusTooManyRequests, nil, "Too many requests")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// İstemci IP'sine göre anahtar
+func ClientIPKey(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// Kimliği doğrulanmış kullanıcıya göre anahtar; JWT middleware'ından sonra kullanılmalıdır
+func UserKey(c *gin.Context) string {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		return ""
+	}
+	return "user:" + strconv.Itoa(userID)
+}
+
+// Başlıklardaki saniye değerleri tam sayıya yukarı yuvarlanır
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestLimiter(rps float64, burst int) *rateLimiter {
+	return &rateLimiter{rps: rps, burst: float64(burst), buckets: map[string]*tokenBucket{}}
+}
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	l := newTestLimiter(2, 3)
+	now := time.Now()
+
+	for want := 2; want >= 0; want-- {
+		allowed, remaining, _, _ := l.take("ip:a", now)
+		if !allowed || remaining != want {
+			t.Fatalf("allowed=%v remaining=%d, want true/%d", allowed, remaining, want)
+		}
+	}
+	allowed, remaining, wait, reset := l.take("ip:a", now)
+	if allowed || remaining != 0 {
+		t.Fatalf("burst exceeded but allowed=%v remaining=%d", allowed, remaining)
+	}
+	if wait != 500*time.Millisecond || reset != 1500*time.Millisecond {
+		t.Fatalf("wait=%v reset=%v, want 500ms/1.5s", wait, reset)
+	}
+
+	// Diğer anahtarlar etkilenmez
+	if allowed, _, _, _ := l.take("ip:b", now); !allowed {
+		t.Fatal("separate key was limited")
+	}
+
+	// Yarım saniyede bir token dolar
+	if allowed, _, _, _ := l.take("ip:a", now.Add(500*time.Millisecond)); !allowed {
+		t.Fatal("token was not refilled")
+	}
+	if allowed, _, _, _ := l.take("ip:a", now.Add(500*time.Millisecond)); allowed {
+		t.Fatal("more tokens than refilled")
+	}
+}
+
+func TestRateLimiterSweepsFullBuckets(t *testing.T) {
+	l := newTestLimiter(1, 2)
+	now := time.Now()
+	l.lastSweep = now
+	l.take("ip:idle", now)
+	l.take("ip:busy", now)
+	l.take("ip:busy", now)
+
+	// Tarama aralığından sonra dolmuş kova silinir, boşalmış kova kalır
+	later := now.Add(rateLimitSweepInterval + time.Second)
+	l.buckets["ip:busy"].last = later
+	l.take("ip:other", later)
+	if _, exists := l.buckets["ip:idle"]; exists {
+		t.Error("full bucket was not swept")
+	}
+	if _, exists := l.buckets["ip:busy"]; !exists {
+		t.Error("draining bucket was swept")
+	}
+}
+
+func TestCeilSeconds(t *testing.T) {
+	cases := map[time.Duration]int{0: 0, time.Millisecond: 1, time.Second: 1, 1500 * time.Millisecond: 2}
+	for d, want := range cases {
+		if got := ceilSeconds(d); got != want {
+			t.Errorf("ceilSeconds(%v) = %d, want %d", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"priviatodolist/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID gelen X-Request-ID değerini kabul eder, yoksa veya geçersizse yenisini üretir.
+// Değer istek başlığına yazılır (loglar ve audit kayıtları buradan okur) ve yanıtta geri döner.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = utils.RandomToken(16)
+			c.Request.Header.Set(RequestIDHeader, requestID)
+		}
+		c.Set("requestID", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// En fazla 128 karakter; yalnızca harf, rakam ve ._:- karakterleri (log ve başlık enjeksiyonuna karşı)
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.' || r == '_' || r == ':' || r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"log/slog"
+	"priviatodolist/logging"
+	"priviatodolist/tracing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLogger her istek için request ID, yöntem, rota ve maskelenmiş yol taşıyan bir logger
+// oluşturur ve istek bitince tek satırlık erişim kaydı yazar. Kimlik doğrulama sonrası
+// eklenen kullanıcı bilgisi de bu kayda girer.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		attrs := []any{
+			"method", c.Request.Method,
+			"route", c.FullPath(),
+			"path", logging.RedactPath(c.Request.URL),
+		}
+		if requestID := c.GetHeader(RequestIDHeader); requestID != "" {
+			attrs = append(attrs, "request_id", requestID)
+		}
+		if span := tracing.FromContext(c.Request.Context()); span != nil {
+			attrs = append(attrs, "trace_id", span.TraceIDString())
+		}
+		c.Request = c.Request.WithContext(logging.WithAttrs(c.Request.Context(), attrs...))
+
+		// Panic durumunda da erişim kaydı yazılır; panic'i GlobalErrorHandler yakalar
+		completed := false
+		defer func() {
+			status := responseStatus(c, completed)
+			level := slog.LevelInfo
+			if status >= 500 {
+				level = slog.LevelError
+			} else if status >= 400 {
+				level = slog.LevelWarn
+			}
+			ctx := c.Request.Context()
+			logging.FromContext(ctx).Log(ctx, level, "request",
+				"status", status,
+				"duration_ms", float64(time.Since(start).Microseconds())/1000,
+				"bytes", c.Writer.Size(),
+				"client_ip", c.ClientIP(),
+			)
+		}()
+
+		c.Next()
+		completed = true
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"priviatodolist/logging"
+	"priviatodolist/tracing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Tracing her istek için bir sunucu span'ı açar. Gelen traceparent başlığı varsa span
+// çağıranın trace'ine bağlanır; servis ve depolama span'ları bu span'ın altında oluşur.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := tracing.StartServer(c.Request.Context(), c.Request.Method+" "+route, c.GetHeader("traceparent"))
+		span.SetAttr("http.request.method", c.Request.Method)
+		span.SetAttr("http.route", route)
+		span.SetAttr("url.path", logging.RedactPath(c.Request.URL))
+		span.SetAttr("request_id", c.GetHeader(RequestIDHeader))
+		c.Request = c.Request.WithContext(ctx)
+
+		// Panic durumunda da span kapatılır; panic'i GlobalErrorHandler yakalar
+		completed := false
+		defer func() {
+			status := responseStatus(c, completed)
+			span.SetAttr("http.response.status_code", status)
+			span.SetAttr("code.function", c.HandlerName())
+			if userID, exists := c.Get("userID"); exists {
+				span.SetAttr("user_id", userID)
+			}
+			if !completed {
+				span.SetError(errors.New("panic"))
+			} else if status >= 500 {
+				span.SetError(errors.New(http.StatusText(status)))
+			}
+			span.End()
+		}()
+
+		c.Next()
+		completed = true
+	}
+}
@@ -3,37 +3,42 @@ package mockdb
 import (
 	"priviatodolist/models"
 	"time"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
-// Kullanıcı adı ve şifrelerin bulunduğu veritabanı
-var Users = map[string]string{
-	"user1":  "1234",
-	"admin1": "admin",
-	"user2":  "abcd",
-	"user3":  "pass123",
-	"user4":  "qwerty",
-	"user5":  "zxcvbn",
+// Kullanıcıların bulunduğu veritabanı (şifreler bcrypt ile saklanır)
+var Users = map[int]*models.User{
+	1: seedUser(1, "user1", "1234", "user"),
+	2: seedUser(2, "admin1", "admin", "admin"),
+	3: seedUser(3, "user2", "abcd", "user"),
+	4: seedUser(4, "user3", "pass123", "user"),
+	5: seedUser(5, "user4", "qwerty", "user"),
+	6: seedUser(6, "user5", "zxcvbn", "user"),
 }
 
-// Kullanıcı rollerinin bulunduğu veritabanı
-var UserRoles = map[string]string{
-	"user1":  "user",
-	"admin1": "admin",
-	"user2":  "user",
-	"user3":  "user",
-	"user4":  "user",
-	"user5":  "user",
-}
+// Oturumların bulunduğu veritabanı (oturum ID'si -> oturum)
+var Sessions = map[string]*models.Session{}
 
-// Kullanıcı ID'lerini içeren veritabanı (her kullanıcıya bir benzersiz ID atamak için)
-var UserIDs = map[string]int{
-	"user1":  1,
-	"admin1": 2,
-	"user2":  3,
-	"user3":  4,
-	"user4":  5,
-	"user5":  6,
-}
+// Denetim kayıtları (yalnızca ekleme yapılır)
+var AuditLog []*models.AuditEvent
+
+// Liste ve item domain event'leri (yalnızca ekleme yapılır)
+var EventLog []*models.DomainEvent
+
+// Webhook'lar ve teslimat kayıtları
+var Webhooks = map[int]*models.Webhook{}
+var WebhookDeliveries = map[int]*models.WebhookDelivery{}
+
+// Idempotency-Key kayıtları ("<kullanıcı ID>:<anahtar>" -> saklanan yanıt)
+var IdempotencyRecords = map[string]*models.IdempotencyRecord{}
+
+// Başarısız giriş denemeleri (kullanıcı adı -> deneme kaydı)
+var LoginAttempts = map[string]*models.LoginAttempt{}
+
+// Item ve liste sürüm geçmişleri (ID -> eskiden yeniye sürümler)
+var ItemVersions = map[int][]*models.TodoItemVersion{}
+var ListVersions = map[int][]*models.TodoListVersion{}
 
 // Todo listelerini temsil eden veritabanı
 var TodoLists = map[int]*models.TodoList{
@@ -187,8 +192,29 @@ var TodoItems = map[int]*models.TodoItem{
 // ID sayaçları
 var TodoListIDCounter = 4
 var TodoItemIDCounter = 6
+var UserIDCounter = 7
+var AuditEventIDCounter = 1
+var EventSeqCounter int64 = 1
+var WebhookIDCounter = 1
+var WebhookDeliveryIDCounter = 1
 
 // Şu anki UTC zamanını döndüren fonksiyon
 func GetCurrentTime() time.Time {
 	return time.Now()
 }
+
+// Başlangıç kullanıcısını hash'lenmiş şifresiyle oluşturur
+func seedUser(id int, username, password, role string) *models.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+	return &models.User{
+		ID:        id,
+		Username:  username,
+		Password:  string(hash),
+		Role:      role,
+		CreatedAt: GetCurrentTime(),
+		UpdatedAt: GetCurrentTime(),
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Denetim kaydı. Eklendikten sonra değiştirilmez; yalnızca hesap silinirken kişisel alanları anonimleştirilir.
+type AuditEvent struct {
+	ID             int                    `json:"id"`
+	Time           time.Time              `json:"time"`
+	ActorID        int                    `json:"actor_id"`
+	ActorUsername  string                 `json:"actor_username"`
+	ImpersonatorID int                    `json:"impersonator_id,omitempty"`
+	Action         string                 `json:"action"`
+	TargetType     string                 `json:"target_type"`
+	TargetID       int                    `json:"target_id"`
+	Before         json.RawMessage        `json:"before,omitempty"`
+	After          json.RawMessage        `json:"after,omitempty"`
+	Changes        map[string]AuditChange `json:"changes,omitempty"`
+	RequestID      string                 `json:"request_id,omitempty"`
+	IP             string                 `json:"ip"`
+}
+
+// Bir alanın önceki ve sonraki değeri
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Denetim kaydı sorgu filtresi; boş alanlar filtrelenmez
+type AuditFilter struct {
+	ActorID    int
+	Action     string
+	TargetType string
+	TargetID   int
+	From       time.Time
+	To         time.Time
+	Limit      int
+}
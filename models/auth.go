@@ -1,11 +1,18 @@
 package models
 
+import "time"
+
 // Kullanıcı bilgileri
 type User struct {
 	ID       int    `json:"id"`
 	Username string `json:"username"`
-	Password string `json:"password"`
+	Password string `json:"-"` // bcrypt hash
 	Role     string `json:"role"`
+	Disabled bool   `json:"disabled"`
+	// Takvim aboneliği token'ının SHA-256 özeti; token'ın kendisi saklanmaz
+	CalendarTokenHash string    `json:"-"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // Login isteği
@@ -13,3 +20,44 @@ type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+// İsteği yapan kullanıcı (yetki kontrolleri için).
+// ImpersonatorID sıfırdan farklıysa istek bir admin tarafından bu kullanıcı adına yapılıyordur.
+type Actor struct {
+	UserID         int
+	Username       string
+	Role           string
+	ImpersonatorID int
+	IP             string
+	RequestID      string
+}
+
+// Oturum bilgisi; her başarılı login bir oturum açar ve token "sid" claim'i ile oturuma bağlanır
+type Session struct {
+	ID        string     `json:"id"`
+	UserID    int        `json:"user_id"`
+	IP        string     `json:"ip"`
+	UserAgent string     `json:"user_agent"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	// Impersonation oturumlarında oturumu açan admin
+	ImpersonatorID int `json:"impersonator_id,omitempty"`
+}
+
+// Admin: yeni kullanıcı oluşturma isteği
+type UserCreate struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Role     string `json:"role" binding:"required"`
+}
+
+// Admin: rol değiştirme isteği
+type UserRoleUpdate struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// Admin: şifre sıfırlama isteği
+type PasswordReset struct {
+	Password string `json:"password" binding:"required"`
+}
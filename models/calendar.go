@@ -0,0 +1,7 @@
+package models
+
+// Kullanıcının özel takvim aboneliği; token yalnızca oluşturulurken bir kez döner
+type CalendarFeed struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
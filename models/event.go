@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Liste ve item domain event türleri
+const (
+	EventListCreated  = "ListCreated"
+	EventListRenamed  = "ListRenamed"
+	EventListDeleted  = "ListDeleted"
+	EventListRestored = "ListRestored"
+	EventListPurged   = "ListPurged"
+
+	EventItemAdded       = "ItemAdded"
+	EventItemEdited      = "ItemEdited"
+	EventItemCompleted   = "ItemCompleted"
+	EventItemReopened    = "ItemReopened"
+	EventItemRescheduled = "ItemRescheduled"
+	EventItemReverted    = "ItemReverted"
+	EventItemDeleted     = "ItemDeleted"
+	EventItemRestored    = "ItemRestored"
+	EventItemPurged      = "ItemPurged"
+)
+
+// Event log'a eklenen değiştirilemez domain event'i.
+// Listelerin ve item'ların güncel hali bu event'lerin sırayla uygulanmasıyla oluşur.
+type DomainEvent struct {
+	Seq     int64      `json:"seq"`
+	Type    string     `json:"type"`
+	Time    time.Time  `json:"time"`
+	ActorID int        `json:"actor_id"`
+	OwnerID int        `json:"owner_id"` // listenin sahibi
+	ListID  int        `json:"list_id"`
+	ItemID  int        `json:"item_id,omitempty"`
+	Name    string     `json:"name,omitempty"`     // ListCreated, ListRenamed
+	Content string     `json:"content,omitempty"`  // ItemAdded, ItemEdited, ItemReverted
+	IsDone  bool       `json:"is_done,omitempty"`  // ItemAdded, ItemReverted
+	DueDate *time.Time `json:"due_date,omitempty"` // ItemAdded, ItemRescheduled, ItemReverted
+	Version int        `json:"version,omitempty"`  // ItemReverted: geri dönülen sürüm
+}
+
+// Domain event türlerinin dış sistemlere (webhook, stream) gönderilen isimleri
+var PublicEventNames = map[string]string{
+	EventListCreated:     "list.created",
+	EventListRenamed:     "list.updated",
+	EventListDeleted:     "list.deleted",
+	EventListRestored:    "list.restored",
+	EventListPurged:      "list.purged",
+	EventItemAdded:       "item.created",
+	EventItemEdited:      "item.updated",
+	EventItemCompleted:   "item.completed",
+	EventItemReopened:    "item.reopened",
+	EventItemRescheduled: "item.updated",
+	EventItemReverted:    "item.updated",
+	EventItemDeleted:     "item.deleted",
+	EventItemRestored:    "item.restored",
+	EventItemPurged:      "item.purged",
+}
@@ -0,0 +1,20 @@
+package models
+
+// Liste dışa/içe aktarma formatları
+const (
+	ExportJSON     = "json"
+	ExportCSV      = "csv"
+	ExportMarkdown = "md"
+)
+
+// JSON dışa aktarma biçimi; içe aktarma da aynı biçimi kabul eder
+type TodoListExport struct {
+	Name  string           `json:"name"`
+	Items []TodoItemCreate `json:"items"`
+}
+
+// İçe aktarılan dosyadaki geçersiz bir satır veya öğe
+type ImportProblem struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// Sağlık kontrolü sonuçları
+const (
+	HealthOK          = "ok"
+	HealthUnavailable = "unavailable"
+)
+
+// /readyz yanıtı; checks her kontrolün sonucunu veya hata nedenini içerir
+type HealthStatus struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
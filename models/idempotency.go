@@ -0,0 +1,20 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// Idempotency-Key ile yapılan isteğin saklanan yanıtı.
+// Completed false ise ilk istek hâlâ işleniyordur.
+type IdempotencyRecord struct {
+	Key         string
+	UserID      int
+	Fingerprint string
+	Completed   bool
+	StatusCode  int
+	Header      http.Header // handler'ın eklediği başlıklar (Content-Type, Content-Disposition ...)
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Kullanıcı adına göre ardışık başarısız giriş denemeleri.
+// LockedUntil gelecekteyse bu kullanıcı adıyla giriş denenemez.
+type LoginAttempt struct {
+	Username    string
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// Başarısız girişlerde kilitleme kuralı: Threshold hatadan sonra hesap BaseDelay süresince kilitlenir,
+// sonraki her hatada süre ikiye katlanır (en fazla MaxDelay). Threshold 0 ise kilitleme yapılmaz.
+type LoginLockout struct {
+	Threshold int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
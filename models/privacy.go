@@ -0,0 +1,12 @@
+package models
+
+// DELETE /me isteği; hesap silme şifre ile onaylanır
+type AccountDelete struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// Veri dışa aktarma arşivindeki history.json içeriği
+type DataExportHistory struct {
+	Lists map[int][]*TodoListVersion `json:"lists"`
+	Items map[int][]*TodoItemVersion `json:"items"`
+}
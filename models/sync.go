@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// GET /sync yanıtı: token'dan sonra değişen kayıtların güncel hali.
+// Silinmiş kayıtlar deleted_at ile, kalıcı silinenler purged_* ID'leri ile döner;
+// kalıcı silinen bir listenin tüm item'ları da silinmiş sayılmalıdır.
+type SyncChanges struct {
+	Lists       []*TodoList `json:"lists"`
+	Items       []*TodoItem `json:"items"`
+	PurgedLists []int       `json:"purged_lists"`
+	PurgedItems []int       `json:"purged_items"`
+	Token       string      `json:"token"`
+}
+
+// Çakışma stratejileri
+const (
+	SyncLastWriterWins = "lww"    // updated_at daha yeni olan kazanır
+	SyncReject         = "reject" // token'dan sonra sunucuda değişmiş kayıtlar reddedilir
+)
+
+// POST /sync isteği
+type SyncPush struct {
+	Token    string           `json:"token"`
+	Strategy string           `json:"strategy"`
+	Lists    []SyncListChange `json:"lists"`
+	Items    []SyncItemChange `json:"items"`
+}
+
+// İstemcideki bir liste değişikliği; id boşsa yeni listedir ve client_id ile eşleştirilir
+type SyncListChange struct {
+	ClientID  string    `json:"client_id"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Deleted   bool      `json:"deleted"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// İstemcideki bir item değişikliği; aynı istekte oluşturulan listeye list_client_id ile eklenebilir
+type SyncItemChange struct {
+	ClientID     string     `json:"client_id"`
+	ID           int        `json:"id"`
+	ListID       int        `json:"list_id"`
+	ListClientID string     `json:"list_client_id"`
+	Content      string     `json:"content"`
+	IsDone       bool       `json:"is_done"`
+	DueDate      *time.Time `json:"due_date"`
+	Deleted      bool       `json:"deleted"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Kayıt bazında senkronizasyon sonuçları
+const (
+	SyncApplied   = "applied"
+	SyncUnchanged = "unchanged"
+	SyncConflict  = "conflict"
+	SyncRejected  = "rejected"
+)
+
+// Bir kaydın senkronizasyon sonucu; çakışmada sunucudaki güncel hal döner
+type SyncResult struct {
+	Type     string    `json:"type"`
+	ClientID string    `json:"client_id,omitempty"`
+	ID       int       `json:"id"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+	List     *TodoList `json:"list,omitempty"`
+	Item     *TodoItem `json:"item,omitempty"`
+}
+
+// POST /sync yanıtı
+type SyncPushResult struct {
+	Results []SyncResult `json:"results"`
+	Token   string       `json:"token"`
+}
@@ -20,19 +20,22 @@ type TodoItem struct {
 	ListID    int        `json:"list_id"`
 	Content   string     `json:"content" default:""`
 	IsDone    bool       `json:"is_done" default:"false"`
+	DueDate   *time.Time `json:"due_date"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
 }
 
 type TodoItemUpdate struct {
-	Content string `json:"content"`
-	IsDone  bool   `json:"is_done"`
+	Content string     `json:"content"`
+	IsDone  bool       `json:"is_done"`
+	DueDate *time.Time `json:"due_date"`
 }
 
 type TodoItemCreate struct {
-	Content string `json:"content"`
-	IsDone  bool   `json:"is_done"`
+	Content string     `json:"content"`
+	IsDone  bool       `json:"is_done"`
+	DueDate *time.Time `json:"due_date"`
 }
 
 type TodoListCreate struct {
@@ -41,3 +44,91 @@ type TodoListCreate struct {
 type TodoListUpdate struct {
 	Name string `json:"name"`
 }
+
+// Kullanıcının çöp kutusu (soft delete edilmiş listeler ve item'lar)
+type Trash struct {
+	Lists []*TodoList `json:"lists"`
+	Items []*TodoItem `json:"items"`
+}
+
+// Kalıcı silme sonucu
+type PurgeResult struct {
+	Lists int `json:"lists"`
+	Items int `json:"items"`
+}
+
+// Bir item'ın belirli bir değişiklikten sonraki hali
+type TodoItemVersion struct {
+	Version   int        `json:"version"`
+	ItemID    int        `json:"item_id"`
+	ListID    int        `json:"list_id"`
+	Action    string     `json:"action"`
+	Content   string     `json:"content"`
+	IsDone    bool       `json:"is_done"`
+	DueDate   *time.Time `json:"due_date"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	ChangedBy int        `json:"changed_by"`
+}
+
+// Bir listenin belirli bir değişiklikten sonraki hali
+type TodoListVersion struct {
+	Version   int        `json:"version"`
+	ListID    int        `json:"list_id"`
+	Action    string     `json:"action"`
+	Name      string     `json:"name"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	ChangedBy int        `json:"changed_by"`
+}
+
+// Listenin ve içindeki item'ların değişiklik geçmişi
+type TodoListHistory struct {
+	List  []*TodoListVersion `json:"list"`
+	Items []*TodoItemVersion `json:"items"`
+}
+
+// Toplu item işlemi modları
+const (
+	BatchAtomic  = "atomic"  // bir işlem bile geçersizse hiçbiri uygulanmaz
+	BatchPartial = "partial" // her işlem ayrı uygulanır, sonuçları ayrı döner
+)
+
+// Toplu item işlemi türleri
+const (
+	BatchOpCreate   = "create"
+	BatchOpUpdate   = "update"
+	BatchOpDelete   = "delete"
+	BatchOpComplete = "complete"
+)
+
+// POST /todolists/:id/items/batch isteği
+type ItemBatchRequest struct {
+	Mode       string               `json:"mode"`
+	Operations []ItemBatchOperation `json:"operations" binding:"required"`
+}
+
+type ItemBatchOperation struct {
+	Op      string     `json:"op"`
+	ID      int        `json:"id"`
+	Content string     `json:"content"`
+	IsDone  bool       `json:"is_done"`
+	DueDate *time.Time `json:"due_date"`
+}
+
+// Tek bir işlemin sonucu; status "ok", "failed" veya "skipped" olabilir
+type ItemBatchResult struct {
+	Index  int       `json:"index"`
+	Op     string    `json:"op"`
+	ID     int       `json:"id"`
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+	Item   *TodoItem `json:"item,omitempty"`
+}
+
+// Toplu işlem yanıtı; list alanı completion oranı bir kez hesaplanmış güncel listedir
+type ItemBatchResponse struct {
+	Applied bool              `json:"applied"`
+	Results []ItemBatchResult `json:"results"`
+	List    *TodoList         `json:"list"`
+}
@@ -0,0 +1,172 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// İzinler "kaynak:eylem:kapsam" biçimindedir. Kapsam "own" ise yalnızca
+// kullanıcının kendi kayıtları, "any" ise tüm kayıtlar için geçerlidir.
+const (
+	ListsReadOwn      = "lists:read:own"
+	ListsReadAny      = "lists:read:any"
+	ListsWriteOwn     = "lists:write:own"
+	ListsWriteAny     = "lists:write:any"
+	ItemsReadOwn      = "items:read:own"
+	ItemsReadAny      = "items:read:any"
+	ItemsWriteOwn     = "items:write:own"
+	ItemsWriteAny     = "items:write:any"
+	UsersManage       = "users:manage"
+	TrashPurge        = "trash:purge"
+	WebhooksManageOwn = "webhooks:manage:own"
+	// Admin'in başka bir kullanıcı adına işlem yapabilmesi
+	UsersImpersonate = "users:impersonate"
+	AuditRead        = "audit:read"
+	EventsManage     = "events:manage"
+)
+
+// Kaynak ve eylem isimleri
+const (
+	ResourceLists    = "lists"
+	ResourceItems    = "items"
+	ResourceWebhooks = "webhooks"
+	ActionRead       = "read"
+	ActionWrite      = "write"
+	ActionManage     = "manage"
+	ScopeOwn         = "own"
+	ScopeAny         = "any"
+)
+
+// Bilinen kaynak -> eylem -> izin verilen kapsamlar. Kapsamı olmayan izinler "kaynak:eylem" biçimindedir.
+var knownPermissions = map[string]map[string][]string{
+	ResourceLists:    {ActionRead: {ScopeOwn, ScopeAny}, ActionWrite: {ScopeOwn, ScopeAny}},
+	ResourceItems:    {ActionRead: {ScopeOwn, ScopeAny}, ActionWrite: {ScopeOwn, ScopeAny}},
+	ResourceWebhooks: {ActionManage: {ScopeOwn, ScopeAny}},
+	"users":          {ActionManage: nil, "impersonate": nil},
+	"trash":          {"purge": nil},
+	"audit":          {ActionRead: nil},
+	"events":         {ActionManage: nil},
+}
+
+// Varsayılan rol -> izin eşlemesi. RBAC_POLICY_FILE ile ezilebilir.
+var defaultRoles = map[string][]string{
+	"user": {
+		ListsReadOwn, ListsWriteOwn,
+		ItemsReadOwn, ItemsWriteOwn,
+		WebhooksManageOwn,
+	},
+	"admin": {
+		ListsReadOwn, ListsWriteOwn, ListsReadAny,
+		ItemsReadOwn, ItemsWriteOwn, ItemsReadAny,
+		WebhooksManageOwn,
+		UsersManage, UsersImpersonate, TrashPurge, AuditRead, EventsManage,
+	},
+}
+
+var (
+	mu    sync.RWMutex
+	roles = build(defaultRoles)
+)
+
+func build(src map[string][]string) map[string]map[string]bool {
+	out := make(map[string]map[string]bool, len(src))
+	for role, perms := range src {
+		set := make(map[string]bool, len(perms))
+		for _, p := range perms {
+			set[p] = true
+		}
+		out[role] = set
+	}
+	return out
+}
+
+// LoadFile rol -> izin eşlemesini JSON dosyasından yükler.
+// Dosya biçimi: {"user": ["lists:read:own", ...], "admin": [...]}
+func LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var src map[string][]string
+	if err := json.Unmarshal(data, &src); err != nil {
+		return fmt.Errorf("invalid policy file: %w", err)
+	}
+	for _, role := range slices.Sorted(maps.Keys(src)) {
+		if role == "" {
+			return errors.New("invalid policy file: empty role name")
+		}
+		for _, p := range src[role] {
+			if err := validatePermission(p); err != nil {
+				return fmt.Errorf("invalid permission %q for role %q: %w", p, role, err)
+			}
+		}
+	}
+
+	mu.Lock()
+	roles = build(src)
+	mu.Unlock()
+	return nil
+}
+
+// İznin bilinen bir kaynak, eylem ve kapsamdan oluştuğunu kontrol eder
+func validatePermission(p string) error {
+	parts := strings.Split(p, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return errors.New("must be resource:action or resource:action:scope")
+	}
+	actions, ok := knownPermissions[parts[0]]
+	if !ok {
+		return fmt.Errorf("unknown resource %q", parts[0])
+	}
+	scopes, ok := actions[parts[1]]
+	if !ok {
+		return fmt.Errorf("unknown action %q", parts[1])
+	}
+	switch {
+	case len(parts) == 2 && len(scopes) > 0:
+		return fmt.Errorf("scope required (one of %s)", strings.Join(scopes, ", "))
+	case len(parts) == 3 && len(scopes) == 0:
+		return errors.New("permission has no scope")
+	case len(parts) == 3 && !slices.Contains(scopes, parts[2]):
+		return fmt.Errorf("unknown scope %q", parts[2])
+	}
+	return nil
+}
+
+// HasPermission rolün verilen izne sahip olup olmadığını döner.
+// "any" kapsamlı bir izin aynı kaynak ve eylemin "own" kapsamını da kapsar.
+func HasPermission(role, permission string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	set := roles[role]
+	if set[permission] {
+		return true
+	}
+	if strings.HasSuffix(permission, ":own") {
+		return set[strings.TrimSuffix(permission, ":own")+":any"]
+	}
+	return false
+}
+
+// CanAccess kullanıcının ownerID'ye ait bir kaynak üzerinde eylem yapıp yapamayacağını döner.
+func CanAccess(role string, actorID, ownerID int, resource, action string) bool {
+	if HasPermission(role, resource+":"+action+":any") {
+		return true
+	}
+	return actorID == ownerID && HasPermission(role, resource+":"+action+":own")
+}
+
+// RoleExists rolün politikada tanımlı olup olmadığını döner.
+func RoleExists(role string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := roles[role]
+	return ok
+}
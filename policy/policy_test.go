@@ -0,0 +1,97 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test sonunda varsayılan politikaya döner
+func loadPolicy(t *testing.T, content string) error {
+	t.Helper()
+	t.Cleanup(func() {
+		mu.Lock()
+		roles = build(defaultRoles)
+		mu.Unlock()
+	})
+	path := filepath.Join(t.TempDir(), "rbac.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return LoadFile(path)
+}
+
+func TestLoadFileRejectsUnknownPermissions(t *testing.T) {
+	tests := []struct {
+		permission string
+		err        string
+	}{
+		{"lists", "must be resource:action"},
+		{"lists:read:own:extra", "must be resource:action"},
+		{"list:read:own", `unknown resource "list"`},
+		{"lists:delete:own", `unknown action "delete"`},
+		{"lists:read", "scope required"},
+		{"lists:read:all", `unknown scope "all"`},
+		{"users:manage:any", "permission has no scope"},
+		{"trash:purge:own", "permission has no scope"},
+	}
+	for _, tt := range tests {
+		err := loadPolicy(t, `{"user": ["lists:read:own", "`+tt.permission+`"]}`)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: err = %v, want %q", tt.permission, err, tt.err)
+		}
+	}
+
+	// Hatalı dosya mevcut politikayı değiştirmez
+	if !HasPermission("admin", UsersManage) {
+		t.Fatal("rejected policy file replaced the roles")
+	}
+}
+
+func TestLoadFileAcceptsExample(t *testing.T) {
+	data, err := os.ReadFile("../rbac.example.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loadPolicy(t, string(data)); err != nil {
+		t.Fatalf("rbac.example.json: %v", err)
+	}
+
+	if err := loadPolicy(t, `{"auditor": ["audit:read", "lists:read:any", "webhooks:manage:any"]}`); err != nil {
+		t.Fatal(err)
+	}
+	if !RoleExists("auditor") || RoleExists("admin") {
+		t.Fatal("policy file roles were not applied")
+	}
+}
+
+func TestScopeResolution(t *testing.T) {
+	if err := loadPolicy(t, `{"owner": ["lists:read:own"], "reader": ["lists:read:any"], "none": []}`); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		role           string
+		actorID, owner int
+		want           bool
+	}{
+		{"owner", 1, 1, true},
+		{"owner", 1, 2, false},
+		// any kapsamı başkalarının ve kendi kayıtlarını kapsar
+		{"reader", 1, 2, true},
+		{"reader", 1, 1, true},
+		{"none", 1, 1, false},
+		{"missing", 1, 1, false},
+	}
+	for _, tt := range tests {
+		if got := CanAccess(tt.role, tt.actorID, tt.owner, ResourceLists, ActionRead); got != tt.want {
+			t.Errorf("CanAccess(%s, %d, %d) = %v, want %v", tt.role, tt.actorID, tt.owner, got, tt.want)
+		}
+	}
+	if CanAccess("reader", 1, 2, ResourceLists, ActionWrite) {
+		t.Error("read permission granted write access")
+	}
+	if !HasPermission("reader", ListsReadOwn) || HasPermission("owner", ListsReadAny) {
+		t.Error("own/any resolution is wrong")
+	}
+}
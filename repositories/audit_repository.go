@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Denetim kayıtlarına erişim kilitlenir; okumalar kayıtların kopyalarını döner
+var auditMu sync.Mutex
+
+// Denetim kaydı ekle. Kayıtlar sonradan değiştirilmez; tek istisna hesap silinirken
+// AnonymizeAuditEvents ile kişisel verilerin temizlenmesidir.
+func AppendAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	event.ID = mockdb.AuditEventIDCounter
+	mockdb.AuditEventIDCounter++
+	event.Time = time.Now()
+
+	stored := *event
+	mockdb.AuditLog = append(mockdb.AuditLog, &stored)
+	return event, nil
+}
+
+// Filtreye uyan denetim kayıtlarını eskiden yeniye getir.
+// Action filtresi "item." gibi bir önekle de eşleşir.
+func QueryAuditEvents(filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	var result []*models.AuditEvent
+	for _, event := range mockdb.AuditLog {
+		if filter.ActorID != 0 && event.ActorID != filter.ActorID {
+			continue
+		}
+		if filter.Action != "" && event.Action != filter.Action &&
+			!(strings.HasSuffix(filter.Action, ".") && strings.HasPrefix(event.Action, filter.Action)) {
+			continue
+		}
+		if filter.TargetType != "" && event.TargetType != filter.TargetType {
+			continue
+		}
+		if filter.TargetID != 0 && event.TargetID != filter.TargetID {
+			continue
+		}
+		if !filter.From.IsZero() && event.Time.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && event.Time.After(filter.To) {
+			continue
+		}
+		c := *event
+		result = append(result, &c)
+		if filter.Limit > 0 && len(result) >= filter.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// Kullanıcının yaptığı işlemlerde kullanıcı adını ve IP'yi değiştirir,
+// kullanıcıya ve verilen hedeflere (hedef türü -> ID) ait kayıtlarda önceki/sonraki halleri siler.
+// Kayıtların yerinde değiştirildiği tek durumdur; ID, zaman, eylem ve hedef korunur.
+func AnonymizeAuditEvents(userID int, username string, targets map[string]map[int]bool) int {
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	count := 0
+	for _, event := range mockdb.AuditLog {
+		changed := false
+		if event.ActorID == userID {
+			event.ActorUsername = username
+			event.IP = ""
+			changed = true
+		}
+		if targets[event.TargetType][event.TargetID] {
+			event.Before = nil
+			event.After = nil
+			event.Changes = nil
+			changed = true
+		}
+		if changed {
+			count++
+		}
+	}
+	return count
+}
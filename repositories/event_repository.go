@@ -0,0 +1,311 @@
+package repositories
+
+import (
+	"fmt"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"sync"
+	"time"
+)
+
+// Event ekleme ve projeksiyonun uygulanması tek seferde yapılır.
+// Liste, item ve sürüm geçmişi okumaları da bu kilidin okuma tarafını alır.
+var eventMu sync.RWMutex
+
+// Eklenen her event'i sırasıyla alan aboneler (webhook, stream ...)
+var eventSubscribers []func(models.DomainEvent)
+
+// Eklenmiş ama abonelere henüz iletilmemiş event'ler; eventMu altında sıra numarasıyla eklenir.
+// Aboneleri aynı anda tek bir goroutine çağırır (publishMu), böylece event'ler sırasıyla iletilir.
+var (
+	pendingMu     sync.Mutex
+	pendingEvents []models.DomainEvent
+	publishMu     sync.Mutex
+)
+
+// SubscribeEvents, log'a eklenen her event için fn'i sırasıyla çağırır.
+// fn event kilidi bırakıldıktan sonra çağrılır; yavaş bir abone yazmaları bloklamaz ama
+// sonraki event'lerin iletimini geciktirir, bu yüzden fn bloklamamalıdır.
+func SubscribeEvents(fn func(models.DomainEvent)) {
+	publishMu.Lock()
+	defer publishMu.Unlock()
+
+	eventSubscribers = append(eventSubscribers, fn)
+}
+
+// Event'i abonelere iletilmek üzere sıraya koyar; eventMu tutulurken çağrılır
+func queueEvent(event *models.DomainEvent) {
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+
+	pendingEvents = append(pendingEvents, *copyEvent(event))
+}
+
+// Sıradaki event'leri abonelere iletir; eventMu tutulmadan çağrılır.
+// Başka bir goroutine zaten iletiyorsa sıradakileri o iletir, çağıran beklemez.
+func publishPendingEvents() {
+	for {
+		if !publishMu.TryLock() {
+			return
+		}
+		for {
+			pendingMu.Lock()
+			events := pendingEvents
+			pendingEvents = nil
+			pendingMu.Unlock()
+			if len(events) == 0 {
+				break
+			}
+			for _, event := range events {
+				for _, fn := range eventSubscribers {
+					fn(event)
+				}
+			}
+		}
+		publishMu.Unlock()
+
+		// Kilit bırakılırken sıraya eklenen event kalmadıysa bitti
+		pendingMu.Lock()
+		empty := len(pendingEvents) == 0
+		pendingMu.Unlock()
+		if empty {
+			return
+		}
+	}
+}
+
+// Event'i log'a ekler ve projeksiyona uygular. Yeni liste ve item'lara ID burada verilir.
+func AppendEvent(event *models.DomainEvent) (*models.DomainEvent, error) {
+	eventMu.Lock()
+	appended, err := appendEvent(event)
+	if err != nil {
+		eventMu.Unlock()
+		return nil, err
+	}
+	queueEvent(appended)
+	result := copyEvent(appended)
+	eventMu.Unlock()
+
+	publishPendingEvents()
+	return result, nil
+}
+
+// Event'leri tek seferde ekler: biri bile uygulanamazsa hiçbiri log'a eklenmez ve projeksiyon
+// log'dan yeniden kurularak eski haline döner. Aboneler tüm event'ler eklendikten sonra çağrılır.
+// ListID'si verilmemiş item ekleme event'leri aynı çağrıda daha önce oluşturulan listeye eklenir.
+func AppendEvents(events []*models.DomainEvent) ([]*models.DomainEvent, error) {
+	appended, err := appendEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	publishPendingEvents()
+	return appended, nil
+}
+
+func appendEvents(events []*models.DomainEvent) ([]*models.DomainEvent, error) {
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	if storageClosed {
+		return nil, errStorageClosed
+	}
+	createdListID := 0
+	for _, event := range events {
+		if event.Type == models.EventItemAdded && event.ListID == 0 {
+			event.ListID = createdListID
+		}
+		if err := applyNewEvent(event); err != nil {
+			if rebuildErr := rebuildProjection(); rebuildErr != nil {
+				return nil, rebuildErr
+			}
+			return nil, err
+		}
+		if event.Type == models.EventListCreated {
+			createdListID = event.ListID
+		}
+	}
+
+	appended := make([]*models.DomainEvent, 0, len(events))
+	for _, event := range events {
+		commitEvent(event)
+		queueEvent(event)
+		appended = append(appended, copyEvent(event))
+	}
+	return appended, nil
+}
+
+func appendEvent(event *models.DomainEvent) (*models.DomainEvent, error) {
+	if storageClosed {
+		return nil, errStorageClosed
+	}
+	if err := applyNewEvent(event); err != nil {
+		return nil, err
+	}
+	commitEvent(event)
+	return event, nil
+}
+
+// Event'e zamanını ve yeni liste/item ID'sini verir ve projeksiyona uygular
+func applyNewEvent(event *models.DomainEvent) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Type == models.EventListCreated && event.ListID == 0 {
+		event.ListID = mockdb.TodoListIDCounter
+	}
+	if event.Type == models.EventItemAdded && event.ItemID == 0 {
+		event.ItemID = mockdb.TodoItemIDCounter
+	}
+
+	return ApplyEvent(event)
+}
+
+// Projeksiyona uygulanmış event'i sıra numarasıyla log'a ekler
+func commitEvent(event *models.DomainEvent) {
+	// Kalıcı olarak silinen kaydın ad, içerik ve tarih bilgileri önceki event'lerden de silinir
+	switch event.Type {
+	case models.EventListPurged:
+		redactEvents(func(e *models.DomainEvent) bool { return e.ListID == event.ListID })
+	case models.EventItemPurged:
+		redactEvents(func(e *models.DomainEvent) bool { return e.ItemID == event.ItemID })
+	}
+
+	event.Seq = mockdb.EventSeqCounter
+	mockdb.EventSeqCounter++
+	mockdb.EventLog = append(mockdb.EventLog, event)
+}
+
+// Verilen sıra numarasından sonraki event'leri getir
+func GetEventsSince(seq int64) ([]*models.DomainEvent, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	var result []*models.DomainEvent
+	for _, event := range mockdb.EventLog {
+		if event.Seq > seq {
+			result = append(result, copyEvent(event))
+		}
+	}
+	return result, nil
+}
+
+// Event'ler maskelenebildiği (RedactUserEvents) için dışarıya kopyaları verilir
+func copyEvent(event *models.DomainEvent) *models.DomainEvent {
+	c := *event
+	c.DueDate = copyTime(event.DueDate)
+	return &c
+}
+
+// Son event'in sıra numarası
+func GetLastEventSeq() int64 {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	return mockdb.EventSeqCounter - 1
+}
+
+// Event log boşsa mevcut başlangıç verisini event'lere dönüştürür ve projeksiyonu yeniden kurar.
+// Başlangıç verisinde listelerin içindeki item'lar ile item tablosu ayrı tutulduğu için ikisi birleştirilir;
+// çakışmada item tablosundaki kayıt esas alınır.
+func BootstrapEventLog() error {
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	if len(mockdb.EventLog) > 0 {
+		return nil
+	}
+
+	items := map[int]*models.TodoItem{}
+	for _, list := range mockdb.TodoLists {
+		for _, item := range list.Items {
+			items[item.ID] = item
+		}
+	}
+	for id, item := range mockdb.TodoItems {
+		items[id] = item
+	}
+
+	var events []*models.DomainEvent
+	for _, listID := range sortedKeys(mockdb.TodoLists) {
+		list := mockdb.TodoLists[listID]
+		events = append(events, &models.DomainEvent{
+			Type: models.EventListCreated, Time: list.CreatedAt,
+			OwnerID: list.UserID, ListID: list.ID, Name: list.Name,
+		})
+	}
+	for _, itemID := range sortedKeys(items) {
+		item := items[itemID]
+		list, exists := mockdb.TodoLists[item.ListID]
+		if !exists {
+			continue
+		}
+		events = append(events, &models.DomainEvent{
+			Type: models.EventItemAdded, Time: item.CreatedAt,
+			OwnerID: list.UserID, ListID: item.ListID, ItemID: item.ID,
+			Content: item.Content, IsDone: item.IsDone,
+		})
+		if item.DeletedAt != nil {
+			events = append(events, &models.DomainEvent{
+				Type: models.EventItemDeleted, Time: *item.DeletedAt,
+				OwnerID: list.UserID, ListID: item.ListID, ItemID: item.ID,
+			})
+		}
+	}
+	for _, listID := range sortedKeys(mockdb.TodoLists) {
+		list := mockdb.TodoLists[listID]
+		if list.DeletedAt != nil {
+			events = append(events, &models.DomainEvent{
+				Type: models.EventListDeleted, Time: *list.DeletedAt,
+				OwnerID: list.UserID, ListID: list.ID,
+			})
+		}
+	}
+
+	resetProjection()
+	for _, event := range events {
+		if _, err := appendEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Projeksiyonu sıfırlar ve tüm event'leri baştan uygular
+func RebuildProjection() error {
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	return rebuildProjection()
+}
+
+func rebuildProjection() error {
+	resetProjection()
+	for _, event := range mockdb.EventLog {
+		if err := ApplyEvent(event); err != nil {
+			return fmt.Errorf("replay failed at seq %d: %w", event.Seq, err)
+		}
+	}
+	return nil
+}
+
+// Kullanıcının listelerine ait event'lerdeki liste adı, içerik ve tarih bilgilerini siler.
+// Event'lerin türü ve sırası korunduğu için log yeniden oynatılabilir kalır.
+func RedactUserEvents(userID int) int {
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	return redactEvents(func(event *models.DomainEvent) bool { return event.OwnerID == userID })
+}
+
+func redactEvents(match func(event *models.DomainEvent) bool) int {
+	count := 0
+	for _, event := range mockdb.EventLog {
+		if match(event) {
+			event.Name = ""
+			event.Content = ""
+			event.DueDate = nil
+			count++
+		}
+	}
+	return count
+}
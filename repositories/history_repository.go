@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"errors"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"sort"
+)
+
+// Sürümler event'ler uygulanırken eklenir (bkz. projection.go); ekleme fonksiyonları eventMu tutulurken çağrılır.
+// Okuma fonksiyonları sürümlerin kopyalarını döner.
+
+// Item'ın sürüm geçmişine yeni sürüm ekle; sürüm numarası otomatik verilir
+func AppendItemVersion(version *models.TodoItemVersion) (*models.TodoItemVersion, error) {
+	version.Version = len(mockdb.ItemVersions[version.ItemID]) + 1
+	mockdb.ItemVersions[version.ItemID] = append(mockdb.ItemVersions[version.ItemID], version)
+	return version, nil
+}
+
+// Item'ın tüm sürümlerini getir
+func GetItemVersions(itemID int) ([]*models.TodoItemVersion, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	var result []*models.TodoItemVersion
+	for _, version := range mockdb.ItemVersions[itemID] {
+		result = append(result, copyItemVersion(version))
+	}
+	return result, nil
+}
+
+// Item'ın belirli bir sürümünü getir
+func GetItemVersion(itemID, version int) (*models.TodoItemVersion, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	versions := mockdb.ItemVersions[itemID]
+	if version < 1 || version > len(versions) {
+		return nil, errors.New("version not found")
+	}
+	return copyItemVersion(versions[version-1]), nil
+}
+
+// Listedeki tüm item'ların sürümlerini zaman sırasına göre getir
+func GetItemVersionsByListID(listID int) ([]*models.TodoItemVersion, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	var result []*models.TodoItemVersion
+	for _, versions := range mockdb.ItemVersions {
+		for _, version := range versions {
+			if version.ListID == listID {
+				result = append(result, copyItemVersion(version))
+			}
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].UpdatedAt.Equal(result[j].UpdatedAt) {
+			return result[i].ItemID < result[j].ItemID
+		}
+		return result[i].UpdatedAt.Before(result[j].UpdatedAt)
+	})
+	return result, nil
+}
+
+// Listenin sürüm geçmişine yeni sürüm ekle
+func AppendListVersion(version *models.TodoListVersion) (*models.TodoListVersion, error) {
+	version.Version = len(mockdb.ListVersions[version.ListID]) + 1
+	mockdb.ListVersions[version.ListID] = append(mockdb.ListVersions[version.ListID], version)
+	return version, nil
+}
+
+// Listenin tüm sürümlerini getir
+func GetListVersions(listID int) ([]*models.TodoListVersion, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	var result []*models.TodoListVersion
+	for _, version := range mockdb.ListVersions[listID] {
+		c := *version
+		c.DeletedAt = copyTime(version.DeletedAt)
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func copyItemVersion(version *models.TodoItemVersion) *models.TodoItemVersion {
+	c := *version
+	c.DueDate = copyTime(version.DueDate)
+	c.DeletedAt = copyTime(version.DeletedAt)
+	return &c
+}
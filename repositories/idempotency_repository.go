@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"errors"
+	"net/http"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Süresi dolmuş kayıtlar en fazla bu aralıkla temizlenir; arada süresi dolan kayıtlar rezervasyonda yok sayılır
+const idempotencySweepInterval = time.Minute
+
+// Aynı anahtarla eşzamanlı gelen istekler için kayıtlara erişim kilitlenir ve kopyalar döner
+var (
+	idempotencyMu        sync.Mutex
+	lastIdempotencySweep time.Time
+)
+
+func idempotencyKey(userID int, key string) string {
+	return strconv.Itoa(userID) + ":" + key
+}
+
+// Anahtar boştaysa (veya süresi dolmuşsa) kaydı ekler ve nil döner; aksi halde mevcut kaydı döner
+func ReserveIdempotencyRecord(record *models.IdempotencyRecord) (*models.IdempotencyRecord, error) {
+	idempotencyMu.Lock()
+	defer idempotencyMu.Unlock()
+
+	now := time.Now()
+	if now.Sub(lastIdempotencySweep) > idempotencySweepInterval {
+		sweepIdempotencyRecords(now)
+	}
+
+	id := idempotencyKey(record.UserID, record.Key)
+	if existing, exists := mockdb.IdempotencyRecords[id]; exists && existing.ExpiresAt.After(now) {
+		c := *existing
+		c.Header = existing.Header.Clone()
+		return &c, nil
+	}
+	mockdb.IdempotencyRecords[id] = record
+	return nil, nil
+}
+
+// İşlenen isteğin yanıtını kaydeder
+func CompleteIdempotencyRecord(userID int, key string, statusCode int, header http.Header, body []byte) error {
+	idempotencyMu.Lock()
+	defer idempotencyMu.Unlock()
+
+	record, exists := mockdb.IdempotencyRecords[idempotencyKey(userID, key)]
+	if !exists {
+		return errors.New("idempotency record not found")
+	}
+	record.Completed = true
+	record.StatusCode = statusCode
+	record.Header = header.Clone()
+	record.Body = body
+	return nil
+}
+
+// Kaydı siler; anahtar yeniden kullanılabilir hale gelir
+func DeleteIdempotencyRecord(userID int, key string) {
+	idempotencyMu.Lock()
+	defer idempotencyMu.Unlock()
+
+	delete(mockdb.IdempotencyRecords, idempotencyKey(userID, key))
+}
+
+// Süresi dolmuş kayıtları siler; idempotencyMu tutulurken çağrılmalıdır
+func sweepIdempotencyRecords(now time.Time) {
+	for id, record := range mockdb.IdempotencyRecords {
+		if !record.ExpiresAt.After(now) {
+			delete(mockdb.IdempotencyRecords, id)
+		}
+	}
+	lastIdempotencySweep = now
+}
+
+// Kullanıcının tüm kayıtlarını siler
+func DeleteIdempotencyRecordsByUserID(userID int) {
+	idempotencyMu.Lock()
+	defer idempotencyMu.Unlock()
+
+	for id, record := range mockdb.IdempotencyRecords {
+		if record.UserID == userID {
+			delete(mockdb.IdempotencyRecords, id)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyRecordsAreSweptPeriodically(t *testing.T) {
+	now := time.Now()
+	idempotencyMu.Lock()
+	mockdb.IdempotencyRecords = map[string]*models.IdempotencyRecord{
+		idempotencyKey(1, "old"): {Key: "old", UserID: 1, ExpiresAt: now.Add(-time.Minute)},
+	}
+	lastIdempotencySweep = now
+	idempotencyMu.Unlock()
+
+	// Süresi dolan kayıt tarama aralığı dolmadan silinmez ama yok sayılır
+	existing, _ := ReserveIdempotencyRecord(&models.IdempotencyRecord{Key: "new", UserID: 1, ExpiresAt: now.Add(time.Hour)})
+	if existing != nil {
+		t.Fatal("new key reported as existing")
+	}
+	existing, _ = ReserveIdempotencyRecord(&models.IdempotencyRecord{Key: "old", UserID: 1, Fingerprint: "b", ExpiresAt: now.Add(time.Hour)})
+	if existing != nil {
+		t.Fatal("expired record was not ignored")
+	}
+
+	idempotencyMu.Lock()
+	mockdb.IdempotencyRecords[idempotencyKey(2, "stale")] = &models.IdempotencyRecord{Key: "stale", UserID: 2, ExpiresAt: now.Add(-time.Minute)}
+	lastIdempotencySweep = now.Add(-2 * idempotencySweepInterval)
+	idempotencyMu.Unlock()
+
+	ReserveIdempotencyRecord(&models.IdempotencyRecord{Key: "trigger", UserID: 1, ExpiresAt: now.Add(time.Hour)})
+
+	idempotencyMu.Lock()
+	defer idempotencyMu.Unlock()
+	if _, exists := mockdb.IdempotencyRecords[idempotencyKey(2, "stale")]; exists {
+		t.Fatal("expired record survived the sweep")
+	}
+	if len(mockdb.IdempotencyRecords) != 3 {
+		t.Fatalf("got %d records, want 3", len(mockdb.IdempotencyRecords))
+	}
+}
@@ -7,82 +7,78 @@ import (
 	"time"
 )
 
-func CreateItem(item *models.TodoItem) (*models.TodoItem, error) {
-	item.ID = mockdb.TodoItemIDCounter
-	mockdb.TodoItemIDCounter++
+// Item'lar yalnızca event'ler üzerinden değişir (bkz. projection.go); bu dosya okuma fonksiyonlarını içerir.
+// Dönen item'lar projeksiyonun kopyalarıdır.
 
-	item.CreatedAt = time.Now()
-	item.UpdatedAt = time.Now()
+func GetItemsByListID(listID int, includeDeleted bool) ([]*models.TodoItem, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
 
-	mockdb.TodoItems[item.ID] = item
+	var result []*models.TodoItem
 
-	// Aynı zamanda ilgili listeye de ekleyelim
-	if list, exists := mockdb.TodoLists[item.ListID]; exists {
-		list.Items = append(list.Items, item)
+	if _, exists := mockdb.TodoLists[listID]; !exists {
+		return nil, errors.New("list not found")
 	}
 
-	return item, nil
+	for _, item := range mockdb.TodoItems {
+		if item.ListID == listID {
+			if item.DeletedAt != nil && !includeDeleted {
+				continue
+			}
+			result = append(result, copyItem(item))
+		}
+	}
+	return result, nil
 }
 
-func UpdateItem(itemID int, updated *models.TodoItem) (*models.TodoItem, error) {
+func GetItemByID(itemID int) (*models.TodoItem, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
 	item, exists := mockdb.TodoItems[itemID]
 	if !exists || item.DeletedAt != nil {
 		return nil, errors.New("item not found")
 	}
-
-	item.Content = updated.Content
-	item.IsDone = updated.IsDone
-	item.UpdatedAt = time.Now()
-
-	return item, nil
+	return copyItem(item), nil
 }
 
-func DeleteItem(itemID int) error {
+// Silinmiş item'ı ID ile bul (geri yükleme ve kalıcı silme için)
+func GetDeletedItemByID(itemID int) (*models.TodoItem, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
 	item, exists := mockdb.TodoItems[itemID]
-	if !exists || item.DeletedAt != nil {
-		return errors.New("item not found")
+	if !exists || item.DeletedAt == nil {
+		return nil, errors.New("item not found in trash")
 	}
+	return copyItem(item), nil
+}
 
-	now := time.Now()
-	item.DeletedAt = &now
-	item.UpdatedAt = now
-
-	mockdb.TodoItems[itemID] = item
+// Kullanıcının listelerindeki silinmiş item'ları getir
+func GetDeletedItemsByUserID(userID int) ([]*models.TodoItem, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
 
-	if list, exists := mockdb.TodoLists[item.ListID]; exists {
-		for i, listItem := range list.Items {
-			if listItem.ID == itemID {
-				list.Items[i].DeletedAt = &now
-				list.Items[i].UpdatedAt = now
-				break
-			}
+	var result []*models.TodoItem
+	for _, item := range mockdb.TodoItems {
+		list, exists := mockdb.TodoLists[item.ListID]
+		if exists && list.UserID == userID && item.DeletedAt != nil {
+			result = append(result, copyItem(item))
 		}
 	}
-
-	return nil
+	return result, nil
 }
-func GetItemsByListID(listID int, includeDeleted bool) ([]*models.TodoItem, error) {
-	var result []*models.TodoItem
 
-	if _, exists := mockdb.TodoLists[listID]; !exists {
-		return nil, errors.New("list not found")
-	}
+// Verilen zamandan önce silinmiş tüm item'ları getir
+func GetItemsDeletedBefore(cutoff time.Time) ([]*models.TodoItem, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
 
+	var result []*models.TodoItem
 	for _, item := range mockdb.TodoItems {
-		if item.ListID == listID {
-			if item.DeletedAt != nil && !includeDeleted {
-				continue
-			}
-			result = append(result, item)
+		if item.DeletedAt != nil && item.DeletedAt.Before(cutoff) {
+			result = append(result, copyItem(item))
 		}
 	}
 	return result, nil
 }
-
-func GetItemByID(itemID int) (*models.TodoItem, error) {
-	item, exists := mockdb.TodoItems[itemID]
-	if !exists || item.DeletedAt != nil {
-		return nil, errors.New("item not found")
-	}
-	return item, nil
-}
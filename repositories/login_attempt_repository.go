@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"sync"
+	"time"
+)
+
+// Aynı kullanıcı adıyla eşzamanlı denemeler için kayıtlara erişim kilitlenir ve kopyalar döner
+var loginAttemptMu sync.Mutex
+
+// Bu sayının üzerinde kayıt varsa eski kayıtlar temizlenir (rastgele kullanıcı adlarıyla bellek şişirmeye karşı)
+const maxLoginAttemptRecords = 10000
+
+// Kullanıcı adının deneme kaydı; yoksa sıfır değerli kayıt
+func GetLoginAttempt(username string) models.LoginAttempt {
+	loginAttemptMu.Lock()
+	defer loginAttemptMu.Unlock()
+
+	if attempt, exists := mockdb.LoginAttempts[username]; exists {
+		return *attempt
+	}
+	return models.LoginAttempt{Username: username}
+}
+
+// Başarısız denemeyi kaydeder. Son hata resetAfter'dan eskiyse sayaç sıfırdan başlar.
+// lockFor sıfırdan büyükse kayıt now+lockFor'a kadar kilitlenir; lockFor yeni hata sayısıyla hesaplanır.
+func RecordLoginFailure(username string, now time.Time, resetAfter time.Duration, lockFor func(failures int) time.Duration) models.LoginAttempt {
+	loginAttemptMu.Lock()
+	defer loginAttemptMu.Unlock()
+
+	attempt, exists := mockdb.LoginAttempts[username]
+	if !exists {
+		if len(mockdb.LoginAttempts) >= maxLoginAttemptRecords {
+			deleteStaleLoginAttempts(now.Add(-resetAfter), now)
+		}
+		attempt = &models.LoginAttempt{Username: username}
+		mockdb.LoginAttempts[username] = attempt
+	}
+	if now.Sub(attempt.LastFailure) > resetAfter {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailure = now
+	if d := lockFor(attempt.Failures); d > 0 {
+		attempt.LockedUntil = now.Add(d)
+	}
+	return *attempt
+}
+
+// Başarılı girişten sonra kullanıcı adının sayacını sıfırlar
+func ResetLoginAttempts(username string) {
+	loginAttemptMu.Lock()
+	defer loginAttemptMu.Unlock()
+
+	delete(mockdb.LoginAttempts, username)
+}
+
+func deleteStaleLoginAttempts(before, now time.Time) {
+	for username, attempt := range mockdb.LoginAttempts {
+		if attempt.LastFailure.Before(before) && !attempt.LockedUntil.After(now) {
+			delete(mockdb.LoginAttempts, username)
+		}
+	}
+}
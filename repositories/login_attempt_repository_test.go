@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func noLock(int) time.Duration { return 0 }
+
+func TestLoginFailuresResetAfterQuietPeriod(t *testing.T) {
+	const username = "attempt-reset"
+	t.Cleanup(func() { ResetLoginAttempts(username) })
+	now := time.Now()
+
+	RecordLoginFailure(username, now, time.Hour, noLock)
+	attempt := RecordLoginFailure(username, now.Add(time.Minute), time.Hour, noLock)
+	if attempt.Failures != 2 {
+		t.Fatalf("failures = %d, want 2", attempt.Failures)
+	}
+
+	attempt = RecordLoginFailure(username, now.Add(2*time.Hour), time.Hour, func(failures int) time.Duration {
+		return time.Duration(failures) * time.Minute
+	})
+	if attempt.Failures != 1 {
+		t.Fatalf("failures after quiet period = %d, want 1", attempt.Failures)
+	}
+	if want := now.Add(2*time.Hour + time.Minute); !attempt.LockedUntil.Equal(want) {
+		t.Fatalf("locked until %v, want %v", attempt.LockedUntil, want)
+	}
+
+	ResetLoginAttempts(username)
+	if got := GetLoginAttempt(username); got.Failures != 0 || !got.LockedUntil.IsZero() {
+		t.Fatalf("after reset: %+v", got)
+	}
+}
+
+// Kayıt sınırı dolunca eski ve kilitsiz kayıtlar silinir, kilitli olanlar kalır
+func TestStaleLoginAttemptsAreDropped(t *testing.T) {
+	now := time.Now()
+	loginAttemptMu.Lock()
+	previous := mockdb.LoginAttempts
+	mockdb.LoginAttempts = map[string]*models.LoginAttempt{}
+	for i := range maxLoginAttemptRecords - 1 {
+		name := "stale-" + strconv.Itoa(i)
+		mockdb.LoginAttempts[name] = &models.LoginAttempt{Username: name, Failures: 1, LastFailure: now.Add(-2 * time.Hour)}
+	}
+	mockdb.LoginAttempts["locked"] = &models.LoginAttempt{Username: "locked", Failures: 9, LastFailure: now.Add(-2 * time.Hour), LockedUntil: now.Add(time.Hour)}
+	loginAttemptMu.Unlock()
+	t.Cleanup(func() {
+		loginAttemptMu.Lock()
+		mockdb.LoginAttempts = previous
+		loginAttemptMu.Unlock()
+	})
+
+	RecordLoginFailure("newcomer", now, time.Hour, noLock)
+
+	loginAttemptMu.Lock()
+	defer loginAttemptMu.Unlock()
+	if len(mockdb.LoginAttempts) != 2 {
+		t.Fatalf("%d records left, want 2", len(mockdb.LoginAttempts))
+	}
+	if _, exists := mockdb.LoginAttempts["locked"]; !exists {
+		t.Fatal("locked record was dropped")
+	}
+}
@@ -0,0 +1,293 @@
+package repositories
+
+import (
+	"errors"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"sort"
+	"time"
+)
+
+// ApplyEvent event'i listelerin, item'ların ve sürüm geçmişlerinin güncel haline uygular.
+// Tüm zaman damgaları event zamanından alınır; böylece yeniden oynatma aynı durumu üretir.
+// Varlık ve silinme kontrolleri burada, event kilidi altında yapılır; geçersiz event log'a eklenmez.
+// eventMu tutulurken çağrılmalıdır.
+func ApplyEvent(event *models.DomainEvent) error {
+	t := event.Time
+
+	switch event.Type {
+	case models.EventListCreated:
+		if _, exists := mockdb.TodoLists[event.ListID]; exists {
+			return errors.New("list already exists")
+		}
+		list := &models.TodoList{
+			ID:        event.ListID,
+			UserID:    event.OwnerID,
+			Name:      event.Name,
+			Items:     []*models.TodoItem{},
+			CreatedAt: t,
+			UpdatedAt: t,
+		}
+		mockdb.TodoLists[list.ID] = list
+		if list.ID >= mockdb.TodoListIDCounter {
+			mockdb.TodoListIDCounter = list.ID + 1
+		}
+		appendListVersion(event, list, "create")
+
+	case models.EventListRenamed:
+		list, err := activeList(event.ListID)
+		if err != nil {
+			return err
+		}
+		list.Name = event.Name
+		list.UpdatedAt = t
+		appendListVersion(event, list, "update")
+
+	case models.EventListDeleted:
+		list, err := activeList(event.ListID)
+		if err != nil {
+			return err
+		}
+		list.DeletedAt = &t
+		list.UpdatedAt = t
+		// Silinmemiş item'lar liste ile aynı zamanla silinir; geri yüklemede bu zaman kullanılır
+		for _, item := range list.Items {
+			if item.DeletedAt == nil {
+				item.DeletedAt = &t
+				item.UpdatedAt = t
+				appendItemVersion(event, item, "delete")
+			}
+		}
+		appendListVersion(event, list, "delete")
+
+	case models.EventListRestored:
+		list, err := deletedList(event.ListID)
+		if err != nil {
+			return err
+		}
+		// Listeden önce ayrıca silinmiş item'lar çöp kutusunda kalır
+		deletedAt := *list.DeletedAt
+		for _, item := range list.Items {
+			if item.DeletedAt != nil && item.DeletedAt.Equal(deletedAt) {
+				item.DeletedAt = nil
+				item.UpdatedAt = t
+				appendItemVersion(event, item, "restore")
+			}
+		}
+		list.DeletedAt = nil
+		list.UpdatedAt = t
+		appendListVersion(event, list, "restore")
+
+	case models.EventListPurged:
+		list, err := deletedList(event.ListID)
+		if err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			delete(mockdb.TodoItems, item.ID)
+			delete(mockdb.ItemVersions, item.ID)
+		}
+		delete(mockdb.TodoLists, list.ID)
+		delete(mockdb.ListVersions, list.ID)
+
+	case models.EventItemAdded:
+		list, err := activeList(event.ListID)
+		if err != nil {
+			return err
+		}
+		if _, exists := mockdb.TodoItems[event.ItemID]; exists {
+			return errors.New("item already exists")
+		}
+		item := &models.TodoItem{
+			ID:        event.ItemID,
+			ListID:    event.ListID,
+			Content:   event.Content,
+			IsDone:    event.IsDone,
+			DueDate:   copyTime(event.DueDate),
+			CreatedAt: t,
+			UpdatedAt: t,
+		}
+		mockdb.TodoItems[item.ID] = item
+		list.Items = append(list.Items, item)
+		if item.ID >= mockdb.TodoItemIDCounter {
+			mockdb.TodoItemIDCounter = item.ID + 1
+		}
+		appendItemVersion(event, item, "create")
+
+	case models.EventItemEdited, models.EventItemCompleted, models.EventItemReopened, models.EventItemRescheduled, models.EventItemReverted:
+		item, err := activeItem(event.ListID, event.ItemID)
+		if err != nil {
+			return err
+		}
+		action := "update"
+		switch event.Type {
+		case models.EventItemEdited:
+			item.Content = event.Content
+		case models.EventItemCompleted:
+			item.IsDone = true
+		case models.EventItemReopened:
+			item.IsDone = false
+		case models.EventItemRescheduled:
+			item.DueDate = copyTime(event.DueDate)
+		case models.EventItemReverted:
+			item.Content = event.Content
+			item.IsDone = event.IsDone
+			item.DueDate = copyTime(event.DueDate)
+			action = "revert"
+		}
+		item.UpdatedAt = t
+		appendItemVersion(event, item, action)
+
+	case models.EventItemDeleted:
+		item, err := activeItem(event.ListID, event.ItemID)
+		if err != nil {
+			return err
+		}
+		item.DeletedAt = &t
+		item.UpdatedAt = t
+		appendItemVersion(event, item, "delete")
+
+	case models.EventItemRestored:
+		item, err := deletedItem(event.ListID, event.ItemID)
+		if err != nil {
+			return err
+		}
+		if list, exists := mockdb.TodoLists[item.ListID]; exists && list.DeletedAt != nil {
+			return errors.New("list is deleted")
+		}
+		item.DeletedAt = nil
+		item.UpdatedAt = t
+		appendItemVersion(event, item, "restore")
+
+	case models.EventItemPurged:
+		item, err := deletedItem(event.ListID, event.ItemID)
+		if err != nil {
+			return err
+		}
+		delete(mockdb.TodoItems, item.ID)
+		delete(mockdb.ItemVersions, item.ID)
+		if list, exists := mockdb.TodoLists[item.ListID]; exists {
+			for i, listItem := range list.Items {
+				if listItem.ID == item.ID {
+					list.Items = append(list.Items[:i], list.Items[i+1:]...)
+					break
+				}
+			}
+		}
+
+	default:
+		return errors.New("unknown event type " + event.Type)
+	}
+
+	return nil
+}
+
+// Silinmemiş liste; hata mesajları servislerin döndürdükleriyle aynıdır
+func activeList(listID int) (*models.TodoList, error) {
+	list, exists := mockdb.TodoLists[listID]
+	if !exists || list.DeletedAt != nil {
+		return nil, errors.New("list not found")
+	}
+	return list, nil
+}
+
+func deletedList(listID int) (*models.TodoList, error) {
+	list, exists := mockdb.TodoLists[listID]
+	if !exists || list.DeletedAt == nil {
+		return nil, errors.New("list not found in trash")
+	}
+	return list, nil
+}
+
+// Item event'in listesinde değilse de bulunamamış sayılır
+func activeItem(listID, itemID int) (*models.TodoItem, error) {
+	item, exists := mockdb.TodoItems[itemID]
+	if !exists || item.ListID != listID || item.DeletedAt != nil {
+		return nil, errors.New("item not found")
+	}
+	return item, nil
+}
+
+func deletedItem(listID, itemID int) (*models.TodoItem, error) {
+	item, exists := mockdb.TodoItems[itemID]
+	if !exists || item.ListID != listID || item.DeletedAt == nil {
+		return nil, errors.New("item not found in trash")
+	}
+	return item, nil
+}
+
+func appendItemVersion(event *models.DomainEvent, item *models.TodoItem, action string) {
+	AppendItemVersion(&models.TodoItemVersion{
+		ItemID:    item.ID,
+		ListID:    item.ListID,
+		Action:    action,
+		Content:   item.Content,
+		IsDone:    item.IsDone,
+		DueDate:   copyTime(item.DueDate),
+		UpdatedAt: item.UpdatedAt,
+		DeletedAt: copyTime(item.DeletedAt),
+		ChangedBy: event.ActorID,
+	})
+}
+
+func appendListVersion(event *models.DomainEvent, list *models.TodoList, action string) {
+	AppendListVersion(&models.TodoListVersion{
+		ListID:    list.ID,
+		Action:    action,
+		Name:      list.Name,
+		UpdatedAt: list.UpdatedAt,
+		DeletedAt: copyTime(list.DeletedAt),
+		ChangedBy: event.ActorID,
+	})
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+// Okuma fonksiyonları projeksiyondaki kayıtların kopyalarını döner; kilit dışında değiştirilemezler
+func copyItem(item *models.TodoItem) *models.TodoItem {
+	c := *item
+	c.DueDate = copyTime(item.DueDate)
+	c.DeletedAt = copyTime(item.DeletedAt)
+	return &c
+}
+
+// Listenin kopyası; includeDeletedItems false ise yalnızca silinmemiş item'lar kopyalanır
+func copyList(list *models.TodoList, includeDeletedItems bool) *models.TodoList {
+	c := *list
+	c.DeletedAt = copyTime(list.DeletedAt)
+	c.Items = nil
+	if includeDeletedItems && list.Items != nil {
+		c.Items = make([]*models.TodoItem, 0, len(list.Items))
+	}
+	for _, item := range list.Items {
+		if includeDeletedItems || item.DeletedAt == nil {
+			c.Items = append(c.Items, copyItem(item))
+		}
+	}
+	return &c
+}
+
+// Projeksiyonun tuttuğu tüm durumu temizler
+func resetProjection() {
+	mockdb.TodoLists = map[int]*models.TodoList{}
+	mockdb.TodoItems = map[int]*models.TodoItem{}
+	mockdb.ItemVersions = map[int][]*models.TodoItemVersion{}
+	mockdb.ListVersions = map[int][]*models.TodoListVersion{}
+	mockdb.TodoListIDCounter = 1
+	mockdb.TodoItemIDCounter = 1
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,307 @@
+package repositories
+
+import (
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Her test boş bir event log ve projeksiyonla başlar
+func resetEventStore(t *testing.T) {
+	t.Helper()
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	mockdb.EventLog = nil
+	mockdb.EventSeqCounter = 1
+	storageClosed = false
+	resetProjection()
+}
+
+func mustAppend(t *testing.T, event *models.DomainEvent) *models.DomainEvent {
+	t.Helper()
+	appended, err := AppendEvent(event)
+	if err != nil {
+		t.Fatalf("AppendEvent(%s): %v", event.Type, err)
+	}
+	return appended
+}
+
+// Projeksiyonun karşılaştırılabilir kopyası
+func projectionState() (map[int]models.TodoList, map[int]models.TodoItem, map[int][]models.TodoItemVersion) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	lists := map[int]models.TodoList{}
+	for id, list := range mockdb.TodoLists {
+		c := *list
+		c.Items = nil
+		lists[id] = c
+	}
+	items := map[int]models.TodoItem{}
+	for id, item := range mockdb.TodoItems {
+		items[id] = *copyItem(item)
+	}
+	versions := map[int][]models.TodoItemVersion{}
+	for id, itemVersions := range mockdb.ItemVersions {
+		for _, version := range itemVersions {
+			versions[id] = append(versions[id], *version)
+		}
+	}
+	return lists, items, versions
+}
+
+func TestRebuildProjectionMatchesLiveState(t *testing.T) {
+	resetEventStore(t)
+	due := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	list := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Market"})
+	first := mustAppend(t, &models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "Süt"})
+	second := mustAppend(t, &models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "Ekmek", DueDate: &due})
+	mustAppend(t, &models.DomainEvent{Type: models.EventItemEdited, OwnerID: 1, ListID: list.ListID, ItemID: first.ItemID, Content: "Yağsız süt"})
+	mustAppend(t, &models.DomainEvent{Type: models.EventItemCompleted, OwnerID: 1, ListID: list.ListID, ItemID: first.ItemID})
+	mustAppend(t, &models.DomainEvent{Type: models.EventItemDeleted, OwnerID: 1, ListID: list.ListID, ItemID: second.ItemID})
+	mustAppend(t, &models.DomainEvent{Type: models.EventListRenamed, OwnerID: 1, ListID: list.ListID, Name: "Haftalık market"})
+	mustAppend(t, &models.DomainEvent{Type: models.EventListDeleted, OwnerID: 1, ListID: list.ListID})
+	mustAppend(t, &models.DomainEvent{Type: models.EventListRestored, OwnerID: 1, ListID: list.ListID})
+
+	liveLists, liveItems, liveVersions := projectionState()
+	if err := RebuildProjection(); err != nil {
+		t.Fatalf("RebuildProjection: %v", err)
+	}
+	replayLists, replayItems, replayVersions := projectionState()
+
+	if !reflect.DeepEqual(liveLists, replayLists) {
+		t.Errorf("lists differ after replay:\nlive   %+v\nreplay %+v", liveLists, replayLists)
+	}
+	if !reflect.DeepEqual(liveItems, replayItems) {
+		t.Errorf("items differ after replay:\nlive   %+v\nreplay %+v", liveItems, replayItems)
+	}
+	if !reflect.DeepEqual(liveVersions, replayVersions) {
+		t.Errorf("versions differ after replay")
+	}
+
+	// Listeden önce ayrıca silinen item çöp kutusunda kalır
+	if _, err := GetDeletedItemByID(second.ItemID); err != nil {
+		t.Errorf("item deleted before the list should stay in trash: %v", err)
+	}
+}
+
+func TestApplyEventRejectsInvalidTransitions(t *testing.T) {
+	resetEventStore(t)
+	list := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Liste"})
+	item := mustAppend(t, &models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "İş"})
+	mustAppend(t, &models.DomainEvent{Type: models.EventItemDeleted, OwnerID: 1, ListID: list.ListID, ItemID: item.ItemID})
+
+	tests := []struct {
+		name  string
+		event models.DomainEvent
+		err   string
+	}{
+		{"delete deleted item", models.DomainEvent{Type: models.EventItemDeleted, ListID: list.ListID, ItemID: item.ItemID}, "item not found"},
+		{"edit deleted item", models.DomainEvent{Type: models.EventItemEdited, ListID: list.ListID, ItemID: item.ItemID, Content: "x"}, "item not found"},
+		{"purge active list", models.DomainEvent{Type: models.EventListPurged, ListID: list.ListID}, "list not found in trash"},
+		{"restore active list", models.DomainEvent{Type: models.EventListRestored, ListID: list.ListID}, "list not found in trash"},
+		{"unknown list", models.DomainEvent{Type: models.EventItemAdded, ListID: 999, Content: "x"}, "list not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := GetLastEventSeq()
+			event := tt.event
+			_, err := AppendEvent(&event)
+			if err == nil || err.Error() != tt.err {
+				t.Fatalf("err = %v, want %q", err, tt.err)
+			}
+			if GetLastEventSeq() != before {
+				t.Fatal("rejected event was appended to the log")
+			}
+		})
+	}
+
+	mustAppend(t, &models.DomainEvent{Type: models.EventListDeleted, OwnerID: 1, ListID: list.ListID})
+	_, err := AppendEvent(&models.DomainEvent{Type: models.EventItemAdded, ListID: list.ListID, Content: "x"})
+	if err == nil || err.Error() != "list not found" {
+		t.Fatalf("adding to deleted list: err = %v", err)
+	}
+	_, err = AppendEvent(&models.DomainEvent{Type: models.EventItemRestored, ListID: list.ListID, ItemID: item.ItemID})
+	if err == nil || err.Error() != "list is deleted" {
+		t.Fatalf("restoring item of deleted list: err = %v", err)
+	}
+}
+
+func TestReadsReturnCopies(t *testing.T) {
+	resetEventStore(t)
+	list := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Liste"})
+	item := mustAppend(t, &models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "İlk"})
+
+	readItem, _ := GetItemByID(item.ItemID)
+	readList, _ := GetTodoListByID(list.ListID)
+	mustAppend(t, &models.DomainEvent{Type: models.EventItemEdited, OwnerID: 1, ListID: list.ListID, ItemID: item.ItemID, Content: "İkinci"})
+
+	if readItem.Content != "İlk" || readList.Items[0].Content != "İlk" {
+		t.Fatal("read results changed after a later event")
+	}
+	readItem.Content = "değişti"
+	if current, _ := GetItemByID(item.ItemID); current.Content != "İkinci" {
+		t.Fatalf("changing a read result changed the projection: %q", current.Content)
+	}
+}
+
+// go test -race ile çalıştırıldığında eşzamanlı ekleme ve okumalarda veri yarışı olmamalı
+func TestConcurrentAppendAndRead(t *testing.T) {
+	resetEventStore(t)
+	list := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Liste"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := AppendEvent(&models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "x"}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				lists, _ := GetTodoListsByUserID(1, false)
+				for _, l := range lists {
+					_ = len(l.Items)
+				}
+				_, _ = GetItemsByListID(list.ListID, true)
+				_, _ = GetItemVersionsByListID(list.ListID)
+			}
+		}()
+	}
+	wg.Wait()
+
+	items, _ := GetItemsByListID(list.ListID, false)
+	if len(items) != 200 {
+		t.Fatalf("got %d items, want 200", len(items))
+	}
+}
+
+func TestAppendEventsIsAllOrNothing(t *testing.T) {
+	resetEventStore(t)
+	list := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Liste"})
+	item := mustAppend(t, &models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "İlk"})
+	lastSeq := GetLastEventSeq()
+
+	_, err := AppendEvents([]*models.DomainEvent{
+		{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "Yeni"},
+		{Type: models.EventItemEdited, OwnerID: 1, ListID: list.ListID, ItemID: item.ItemID, Content: "Düzenlendi"},
+		{Type: models.EventItemDeleted, OwnerID: 1, ListID: list.ListID, ItemID: 999},
+	})
+	if err == nil || err.Error() != "item not found" {
+		t.Fatalf("err = %v", err)
+	}
+	if GetLastEventSeq() != lastSeq {
+		t.Fatal("events of a failed batch were appended")
+	}
+	items, _ := GetItemsByListID(list.ListID, true)
+	if len(items) != 1 || items[0].Content != "İlk" {
+		t.Fatalf("projection changed by a failed batch: %+v", items)
+	}
+
+	appended, err := AppendEvents([]*models.DomainEvent{
+		{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "Yeni"},
+		{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "Bir yeni daha"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if appended[0].ItemID == appended[1].ItemID || appended[0].ItemID != item.ItemID+1 {
+		t.Fatalf("item IDs = %d, %d", appended[0].ItemID, appended[1].ItemID)
+	}
+}
+
+func TestItemEventsMustMatchList(t *testing.T) {
+	resetEventStore(t)
+	first := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Birinci"})
+	second := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "İkinci"})
+	item := mustAppend(t, &models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: first.ListID, Content: "x"})
+
+	_, err := AppendEvent(&models.DomainEvent{Type: models.EventItemDeleted, OwnerID: 1, ListID: second.ListID, ItemID: item.ItemID})
+	if err == nil || err.Error() != "item not found" {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// Yavaş bir abone event kilidini tutmamalı; diğer yazma ve okumalar beklemeden devam eder
+// ve abone event'leri yine sırasıyla alır.
+func TestSlowSubscriberDoesNotBlockWrites(t *testing.T) {
+	resetEventStore(t)
+	list := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Liste"})
+
+	var (
+		mu      sync.Mutex
+		active  = true
+		seqs    []int64
+		release = make(chan struct{})
+		entered = make(chan struct{}, 1)
+	)
+	SubscribeEvents(func(event models.DomainEvent) {
+		mu.Lock()
+		if !active {
+			mu.Unlock()
+			return
+		}
+		seqs = append(seqs, event.Seq)
+		mu.Unlock()
+
+		select {
+		case entered <- struct{}{}:
+			<-release
+		default:
+		}
+	})
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		active = false
+	})
+
+	// İlk event'in aboneye iletimi release kapanana kadar bekler
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mustAppend(t, &models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "Bir"})
+	}()
+	<-entered
+
+	appended := make(chan error, 1)
+	go func() {
+		_, err := AppendEvents([]*models.DomainEvent{
+			{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "İki"},
+			{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "Üç"},
+		})
+		appended <- err
+	}()
+	select {
+	case err := <-appended:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatal("AppendEvents blocked on a slow subscriber")
+	}
+	if items, _ := GetItemsByListID(list.ListID, false); len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+
+	close(release)
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(seqs, []int64{2, 3, 4}) {
+		t.Fatalf("subscriber got seqs %v, want [2 3 4]", seqs)
+	}
+}
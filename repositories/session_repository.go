@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"errors"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Oturum kayıtlarına erişim kilitlenir ve kopyalar döner
+var sessionMu sync.Mutex
+
+// Yeni oturum kaydet
+func CreateSession(session *models.Session) (*models.Session, error) {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	if _, exists := mockdb.Sessions[session.ID]; exists {
+		return nil, errors.New("session already exists")
+	}
+	mockdb.Sessions[session.ID] = copySession(session)
+	return session, nil
+}
+
+// Oturumu ID ile bul
+func GetSessionByID(sessionID string) (*models.Session, error) {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	session, exists := mockdb.Sessions[sessionID]
+	if !exists {
+		return nil, errors.New("session not found")
+	}
+	return copySession(session), nil
+}
+
+// Kullanıcının süresi dolmamış ve iptal edilmemiş oturumlarını getir
+func GetActiveSessionsByUserID(userID int) ([]*models.Session, error) {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	now := time.Now()
+	var sessions []*models.Session
+	for _, session := range mockdb.Sessions {
+		if session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(now) {
+			sessions = append(sessions, copySession(session))
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.Before(sessions[j].CreatedAt) })
+	return sessions, nil
+}
+
+// Kullanıcının tüm açık oturumlarını iptal et
+func RevokeUserSessions(userID int) error {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	now := time.Now()
+	for _, session := range mockdb.Sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			revokedAt := now
+			session.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+// Kullanıcının iptal edilmiş ve süresi dolmuş olanlar dahil tüm oturumlarını getir
+func GetSessionsByUserID(userID int) ([]*models.Session, error) {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	var sessions []*models.Session
+	for _, session := range mockdb.Sessions {
+		if session.UserID == userID {
+			sessions = append(sessions, copySession(session))
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].CreatedAt.Before(sessions[j].CreatedAt) })
+	return sessions, nil
+}
+
+// Kullanıcının tüm oturum kayıtlarını sil
+func DeleteUserSessions(userID int) error {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	for id, session := range mockdb.Sessions {
+		if session.UserID == userID {
+			delete(mockdb.Sessions, id)
+		}
+	}
+	return nil
+}
+
+// Süresi dolmamış ve iptal edilmemiş oturumların sayısı
+func CountActiveSessions() int {
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for _, session := range mockdb.Sessions {
+		if session.RevokedAt == nil && session.ExpiresAt.After(now) {
+			count++
+		}
+	}
+	return count
+}
+
+func copySession(session *models.Session) *models.Session {
+	c := *session
+	if session.RevokedAt != nil {
+		revokedAt := *session.RevokedAt
+		c.RevokedAt = &revokedAt
+	}
+	return &c
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import "errors"
+
+// Kapanıştan sonra yeni event kabul edilmez
+var storageClosed bool
+
+var errStorageClosed = errors.New("storage closed")
+
+// Close, devam eden yazmaların bitmesini bekler ve sonraki yazmaları reddeder.
+// Bellek içi depolamada kalıcı hale getirilecek veri yoktur; kalıcı bir depolama
+// eklendiğinde yazılmamış veriler burada diske/veritabanına aktarılmalıdır.
+func Close() error {
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	if storageClosed {
+		return errStorageClosed
+	}
+	storageClosed = true
+	return nil
+}
+
+// Depolama katmanı erişilebilir mi? Bellek içi depolama kapatılana kadar erişilebilirdir.
+func Ping() error {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	if storageClosed {
+		return errStorageClosed
+	}
+	return nil
+}
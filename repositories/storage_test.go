@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"priviatodolist/models"
+	"testing"
+)
+
+func TestClosedStorageRejectsWrites(t *testing.T) {
+	resetEventStore(t)
+	t.Cleanup(func() { resetEventStore(t) })
+	list := mustAppend(t, &models.DomainEvent{Type: models.EventListCreated, OwnerID: 1, Name: "Liste"})
+
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Ping(); err == nil {
+		t.Error("Ping succeeded after Close")
+	}
+	if _, err := AppendEvent(&models.DomainEvent{Type: models.EventItemAdded, OwnerID: 1, ListID: list.ListID, Content: "x"}); err != errStorageClosed {
+		t.Errorf("AppendEvent after Close: err = %v", err)
+	}
+	if _, err := AppendEvents([]*models.DomainEvent{{Type: models.EventListRenamed, OwnerID: 1, ListID: list.ListID, Name: "Yeni"}}); err != errStorageClosed {
+		t.Errorf("AppendEvents after Close: err = %v", err)
+	}
+	if err := Close(); err == nil {
+		t.Error("second Close succeeded")
+	}
+
+	// Okumalar kapanıştan sonra da çalışır; devam eden istekler yanıtlanabilir
+	if _, err := GetTodoListByID(list.ListID); err != nil {
+		t.Errorf("read after Close: %v", err)
+	}
+}
@@ -4,47 +4,34 @@ import (
 	"errors"
 	"priviatodolist/mockdb"
 	"priviatodolist/models"
+	"time"
 )
 
+// Listeler yalnızca event'ler üzerinden değişir (bkz. projection.go); bu dosya okuma fonksiyonlarını içerir.
+// Dönen listeler projeksiyonun kopyalarıdır.
+
 // TodoList'i ID ile bul
 func GetTodoListByID(listID int) (*models.TodoList, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
 	list, exists := mockdb.TodoLists[listID]
 	if !exists {
 		return nil, errors.New("list not found")
 	}
-	return list, nil
-}
-
-// Yeni bir TodoList oluştur
-func CreateTodoList(newList *models.TodoList) (*models.TodoList, error) {
-	mockdb.TodoLists[newList.ID] = newList
-	return newList, nil
-}
-
-// TodoList güncelle
-func UpdateTodoList(listID int, updatedList *models.TodoList) (*models.TodoList, error) {
-	mockdb.TodoLists[listID] = updatedList
-	return updatedList, nil
+	return copyList(list, true), nil
 }
 
 // Kullanıcıya ait TodoList'leri getir
 func GetTodoListsByUserID(userID int, includeDeleted bool) ([]*models.TodoList, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
 	var lists []*models.TodoList
 
 	for _, list := range mockdb.TodoLists {
 		if list.UserID == userID && (includeDeleted || list.DeletedAt == nil) {
-			listCopy := *list
-
-			var activeItems []*models.TodoItem
-			for _, item := range list.Items {
-				if item.DeletedAt == nil {
-					activeItems = append(activeItems, item)
-				}
-			}
-
-			listCopy.Items = activeItems
-
-			lists = append(lists, &listCopy)
+			lists = append(lists, copyList(list, false))
 		}
 	}
 
@@ -53,10 +40,41 @@ func GetTodoListsByUserID(userID int, includeDeleted bool) ([]*models.TodoList,
 
 // Tüm TodoList'leri getir (Admin için)
 func GetAllTodoLists(includeDeleted bool) ([]*models.TodoList, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
 	var lists []*models.TodoList
 	for _, list := range mockdb.TodoLists {
 		if includeDeleted || list.DeletedAt == nil {
-			lists = append(lists, list)
+			lists = append(lists, copyList(list, true))
+		}
+	}
+	return lists, nil
+}
+
+// Kullanıcının silinmiş listelerini getir
+func GetDeletedTodoListsByUserID(userID int) ([]*models.TodoList, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	var lists []*models.TodoList
+	for _, list := range mockdb.TodoLists {
+		if list.UserID == userID && list.DeletedAt != nil {
+			lists = append(lists, copyList(list, true))
+		}
+	}
+	return lists, nil
+}
+
+// Verilen zamandan önce silinmiş tüm listeleri getir
+func GetTodoListsDeletedBefore(cutoff time.Time) ([]*models.TodoList, error) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	var lists []*models.TodoList
+	for _, list := range mockdb.TodoLists {
+		if list.DeletedAt != nil && list.DeletedAt.Before(cutoff) {
+			lists = append(lists, copyList(list, true))
 		}
 	}
 	return lists, nil
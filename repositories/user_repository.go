@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"errors"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kullanıcı kayıtlarına erişim kilitlenir ve kopyalar döner; değişiklikler UpdateUser ile kaydedilir
+var userMu sync.Mutex
+
+// Kullanıcıyı ID ile bul
+func GetUserByID(userID int) (*models.User, error) {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	user, exists := mockdb.Users[userID]
+	if !exists {
+		return nil, errors.New("user not found")
+	}
+	return copyUser(user), nil
+}
+
+// Kullanıcıyı kullanıcı adı ile bul
+func GetUserByUsername(username string) (*models.User, error) {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	if user := findUserByUsername(username); user != nil {
+		return copyUser(user), nil
+	}
+	return nil, errors.New("user not found")
+}
+
+func findUserByUsername(username string) *models.User {
+	for _, user := range mockdb.Users {
+		if user.Username == username {
+			return user
+		}
+	}
+	return nil
+}
+
+// Kullanıcıyı takvim token'ının özeti ile bul
+func GetUserByCalendarTokenHash(hash string) (*models.User, error) {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	for _, user := range mockdb.Users {
+		if user.CalendarTokenHash != "" && user.CalendarTokenHash == hash {
+			return copyUser(user), nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+// Tüm kullanıcıları ID sırasına göre getir
+func GetAllUsers() ([]*models.User, error) {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	return sortedUsers(), nil
+}
+
+func sortedUsers() []*models.User {
+	users := make([]*models.User, 0, len(mockdb.Users))
+	for _, user := range mockdb.Users {
+		users = append(users, copyUser(user))
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users
+}
+
+// Yeni kullanıcı oluştur
+func CreateUser(user *models.User) (*models.User, error) {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	if findUserByUsername(user.Username) != nil {
+		return nil, errors.New("username already exists")
+	}
+
+	user.ID = mockdb.UserIDCounter
+	mockdb.UserIDCounter++
+
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+
+	mockdb.Users[user.ID] = copyUser(user)
+	return user, nil
+}
+
+// Kullanıcıyı güncelle
+func UpdateUser(userID int, updated *models.User) (*models.User, error) {
+	return UpdateUserChecked(userID, updated, nil)
+}
+
+// Kullanıcıyı günceller; check nil değilse güncelleme sonrası tüm kullanıcılarla kilit altında çağrılır
+// ve hata dönerse güncelleme geri alınır. Kontrol ile güncelleme arasına başka bir değişiklik giremez.
+func UpdateUserChecked(userID int, updated *models.User, check func(users []*models.User) error) (*models.User, error) {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	previous, exists := mockdb.Users[userID]
+	if !exists {
+		return nil, errors.New("user not found")
+	}
+	updated.UpdatedAt = time.Now()
+	mockdb.Users[userID] = copyUser(updated)
+	if check != nil {
+		if err := check(sortedUsers()); err != nil {
+			mockdb.Users[userID] = previous
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// Kullanıcıyı kalıcı olarak sil
+func DeleteUser(userID int) error {
+	userMu.Lock()
+	defer userMu.Unlock()
+
+	if _, exists := mockdb.Users[userID]; !exists {
+		return errors.New("user not found")
+	}
+	delete(mockdb.Users, userID)
+	return nil
+}
+
+func copyUser(user *models.User) *models.User {
+	c := *user
+	return &c
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"errors"
+	"priviatodolist/models"
+	"testing"
+)
+
+func TestUpdateUserCheckedRollsBack(t *testing.T) {
+	user, err := CreateUser(&models.User{Username: "rollback-test", Role: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = DeleteUser(user.ID) })
+
+	user.Role = "admin"
+	_, err = UpdateUserChecked(user.ID, user, func(users []*models.User) error {
+		for _, u := range users {
+			if u.ID == user.ID && u.Role != "admin" {
+				t.Error("check did not see the updated user")
+			}
+		}
+		return errors.New("rejected")
+	})
+	if err == nil || err.Error() != "rejected" {
+		t.Fatalf("err = %v", err)
+	}
+	if stored, _ := GetUserByID(user.ID); stored.Role != "user" {
+		t.Fatalf("role = %q after rejected update", stored.Role)
+	}
+}
+
+func TestUserAndSessionReadsReturnCopies(t *testing.T) {
+	user, _ := GetUserByID(1)
+	user.Disabled = true
+	if stored, _ := GetUserByID(1); stored.Disabled {
+		t.Fatal("changing a read user changed the stored user")
+	}
+
+	if _, err := CreateSession(&models.Session{ID: "copy-test", UserID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = DeleteUserSessions(1) })
+	session, _ := GetSessionByID("copy-test")
+	if err := RevokeUserSessions(1); err != nil {
+		t.Fatal(err)
+	}
+	if session.RevokedAt != nil {
+		t.Fatal("read session changed after revoke")
+	}
+	if stored, _ := GetSessionByID("copy-test"); stored.RevokedAt == nil {
+		t.Fatal("session not revoked")
+	}
+}
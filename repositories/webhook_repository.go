@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"errors"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Teslimatlar arka planda güncellendiği için webhook verisine erişim kilitlenir ve kopyalar döner
+var webhookMu sync.Mutex
+
+// Yeni webhook kaydet
+func CreateWebhook(hook *models.Webhook) (*models.Webhook, error) {
+	webhookMu.Lock()
+	defer webhookMu.Unlock()
+
+	hook.ID = mockdb.WebhookIDCounter
+	mockdb.WebhookIDCounter++
+	hook.CreatedAt = time.Now()
+
+	mockdb.Webhooks[hook.ID] = hook
+	c := *hook
+	return &c, nil
+}
+
+// Webhook'u ID ile bul
+func GetWebhookByID(webhookID int) (*models.Webhook, error) {
+	webhookMu.Lock()
+	defer webhookMu.Unlock()
+
+	hook, exists := mockdb.Webhooks[webhookID]
+	if !exists {
+		return nil, errors.New("webhook not found")
+	}
+	c := *hook
+	return &c, nil
+}
+
+// Kullanıcının webhook'larını getir
+func GetWebhooksByUserID(userID int) ([]*models.Webhook, error) {
+	webhookMu.Lock()
+	defer webhookMu.Unlock()
+
+	var result []*models.Webhook
+	for _, hook := range mockdb.Webhooks {
+		if hook.UserID == userID {
+			c := *hook
+			result = append(result, &c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+// Webhook'u ve teslimat kayıtlarını sil
+func DeleteWebhook(webhookID int) error {
+	webhookMu.Lock()
+	defer webhookMu.Unlock()
+
+	if _, exists := mockdb.Webhooks[webhookID]; !exists {
+		return errors.New("webhook not found")
+	}
+	delete(mockdb.Webhooks, webhookID)
+	for id, delivery := range mockdb.WebhookDeliveries {
+		if delivery.WebhookID == webhookID {
+			delete(mockdb.WebhookDeliveries, id)
+		}
+	}
+	return nil
+}
+
+// Yeni teslimat kaydı oluştur
+func CreateWebhookDelivery(delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	webhookMu.Lock()
+	defer webhookMu.Unlock()
+
+	delivery.ID = mockdb.WebhookDeliveryIDCounter
+	mockdb.WebhookDeliveryIDCounter++
+	delivery.Status = models.DeliveryPending
+	delivery.CreatedAt = time.Now()
+	delivery.UpdatedAt = delivery.CreatedAt
+
+	mockdb.WebhookDeliveries[delivery.ID] = delivery
+	c := *delivery
+	return &c, nil
+}
+
+// Teslimat denemesinin sonucunu kaydet
+func UpdateWebhookDelivery(deliveryID int, status string, attempts, statusCode int, errMsg string) (*models.WebhookDelivery, error) {
+	webhookMu.Lock()
+	defer webhookMu.Unlock()
+
+	delivery, exists := mockdb.WebhookDeliveries[deliveryID]
+	if !exists {
+		return nil, errors.New("delivery not found")
+	}
+	delivery.Status = status
+	delivery.Attempts = attempts
+	delivery.StatusCode = statusCode
+	delivery.Error = errMsg
+	delivery.UpdatedAt = time.Now()
+
+	c := *delivery
+	return &c, nil
+}
+
+// Webhook'un teslimat kayıtlarını yeniden eskiye getir
+func GetWebhookDeliveries(webhookID int) ([]*models.WebhookDelivery, error) {
+	webhookMu.Lock()
+	defer webhookMu.Unlock()
+
+	var result []*models.WebhookDelivery
+	for _, delivery := range mockdb.WebhookDeliveries {
+		if delivery.WebhookID == webhookID {
+			c := *delivery
+			result = append(result, &c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package routes
+
+import (
+	"net/http"
+	"priviatodolist/config"
+	"priviatodolist/models"
+	"priviatodolist/services"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestHealthAndVersion(t *testing.T) {
+	r := newTestRouter(t, nil)
+
+	if w := request(r, "GET", "/healthz", "", ""); w.Code != http.StatusOK {
+		t.Fatalf("healthz: %d", w.Code)
+	}
+	w := request(r, "GET", "/version", "", "")
+	var info struct {
+		Commit    string `json:"commit"`
+		BuildTime string `json:"build_time"`
+		GoVersion string `json:"go_version"`
+	}
+	decode(t, w, &info)
+	if info.Commit == "" || info.BuildTime == "" || info.GoVersion != runtime.Version() {
+		t.Fatalf("version = %s", w.Body.String())
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	var cfg *config.Config
+	r := newTestRouter(t, func(c *config.Config) { cfg = c })
+	readiness := func() (int, models.HealthStatus) {
+		w := request(r, "GET", "/readyz", "", "")
+		var status models.HealthStatus
+		decode(t, w, &status)
+		return w.Code, status
+	}
+
+	// Testlerde yalnızca stream broker çalışır; diğer arka plan işleri başlatılmadan servis hazır değildir
+	code, status := readiness()
+	if code != http.StatusServiceUnavailable || status.Checks[services.WorkerTrashRetention] != "not running" || status.Checks["storage"] != models.HealthOK {
+		t.Fatalf("before workers: %d %+v", code, status)
+	}
+
+	stopTrash := services.StartTrashRetention(time.Hour, time.Hour)
+	stopWebhooks := services.StartWebhookDispatcher()
+	defer stopWebhooks()
+	code, status = readiness()
+	if code != http.StatusOK || status.Status != models.HealthOK {
+		t.Fatalf("with workers: %d %+v", code, status)
+	}
+
+	cfg.ListenAddr = ""
+	code, status = readiness()
+	if code != http.StatusServiceUnavailable || status.Checks["config"] == models.HealthOK {
+		t.Fatalf("invalid config: %d %+v", code, status)
+	}
+	cfg.ListenAddr = ":8081"
+
+	stopTrash()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if code, status = readiness(); code == http.StatusServiceUnavailable {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("stopped worker still reported as running")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status.Checks[services.WorkerTrashRetention] != "not running" {
+		t.Fatalf("after stop: %+v", status)
+	}
+}
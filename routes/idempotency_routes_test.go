@@ -0,0 +1,98 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"priviatodolist/config"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	r := newTestRouter(t, nil)
+	token := login(t, r, "user1", "1234")
+
+	first := request(r, "POST", "/api/v1/todolists", token, `{"name":"Idempotent"}`, "Idempotency-Key", "create-list-1")
+	second := request(r, "POST", "/api/v1/todolists", token, `{"name":"Idempotent"}`, "Idempotency-Key", "create-list-1")
+	if first.Code != http.StatusCreated || second.Code != first.Code || second.Body.String() != first.Body.String() {
+		t.Fatalf("first %d %s, second %d %s", first.Code, first.Body.String(), second.Code, second.Body.String())
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" || first.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatal("Idempotent-Replayed header missing on replay")
+	}
+	if second.Header().Get("Content-Type") != first.Header().Get("Content-Type") {
+		t.Fatalf("Content-Type = %q", second.Header().Get("Content-Type"))
+	}
+
+	// Aynı anahtar farklı gövdeyle kullanılamaz; başka kullanıcının aynı anahtarı etkilenmez
+	if w := request(r, "POST", "/api/v1/todolists", token, `{"name":"Başka"}`, "Idempotency-Key", "create-list-1"); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("reused key: %d", w.Code)
+	}
+	other := login(t, r, "user2", "abcd")
+	if w := request(r, "POST", "/api/v1/todolists", other, `{"name":"Başka"}`, "Idempotency-Key", "create-list-1"); w.Code != http.StatusCreated {
+		t.Fatalf("other user's key: %d", w.Code)
+	}
+}
+
+func TestIdempotencyCoversDeleteAndKeepsHeaders(t *testing.T) {
+	r := newTestRouter(t, nil)
+	token := login(t, r, "user1", "1234")
+	listID := strconv.Itoa(createList(t, r, token))
+
+	// Tekrar edilen DELETE yeniden işlenmez ve ilk yanıtı döner (aksi halde 404 olurdu)
+	first := request(r, "DELETE", "/api/v1/todolists/"+listID, token, "", "Idempotency-Key", "delete-"+listID)
+	second := request(r, "DELETE", "/api/v1/todolists/"+listID, token, "", "Idempotency-Key", "delete-"+listID)
+	if first.Code != http.StatusOK || second.Code != http.StatusOK || second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("first %d, second %d %s", first.Code, second.Code, second.Body.String())
+	}
+
+	first = request(r, "POST", "/api/v1/me/export", token, "", "Idempotency-Key", "export-1")
+	second = request(r, "POST", "/api/v1/me/export", token, "", "Idempotency-Key", "export-1")
+	if first.Code != http.StatusOK || second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("export: %d, %d", first.Code, second.Code)
+	}
+	for _, h := range []string{"Content-Type", "Content-Disposition"} {
+		if first.Header().Get(h) == "" || second.Header().Get(h) != first.Header().Get(h) {
+			t.Errorf("%s = %q, want %q", h, second.Header().Get(h), first.Header().Get(h))
+		}
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Error("replayed export body differs")
+	}
+	// İstek ID'si gibi önceki middleware başlıkları saklanmaz, her yanıtta yeniden üretilir
+	if second.Header().Get("X-Request-ID") == first.Header().Get("X-Request-ID") {
+		t.Error("X-Request-ID was replayed")
+	}
+}
+
+// Content-Length bilinmeyen büyük gövde okunurken sınıra takılır ve 413 döner
+func TestIdempotencyRejectsOversizedBody(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) { cfg.MaxBodyBytes = 64 })
+	token := login(t, r, "user1", "1234")
+
+	req := httptest.NewRequest("POST", "/api/v1/todolists", strings.NewReader(`{"name":"`+strings.Repeat("x", 100)+`"}`))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Idempotency-Key", "too-large-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d %s, want 413", w.Code, w.Body.String())
+	}
+}
+
+// Login yanıtı saklanmaz; aynı anahtarla tekrar eden giriş yeni bir token alır
+func TestLoginIsNotIdempotent(t *testing.T) {
+	r := newTestRouter(t, nil)
+	body := `{"username":"user1","password":"1234"}`
+	first := request(r, "POST", "/api/v1/login", "", body, "Idempotency-Key", "login-1")
+	second := request(r, "POST", "/api/v1/login", "", body, "Idempotency-Key", "login-1")
+	if first.Code != http.StatusOK || second.Code != http.StatusOK || second.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("first %d, second %d (replayed %q)", first.Code, second.Code, second.Header().Get("Idempotent-Replayed"))
+	}
+	if first.Body.String() == second.Body.String() {
+		t.Fatal("login response was replayed")
+	}
+}
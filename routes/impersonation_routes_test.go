@@ -0,0 +1,85 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func impersonate(t *testing.T, r http.Handler, adminToken string, userID int) string {
+	t.Helper()
+	w := request(r, "POST", "/api/v1/admin/users/"+strconv.Itoa(userID)+"/impersonate", adminToken, "")
+	var body struct {
+		Token string `json:"token"`
+	}
+	decode(t, w, &body)
+	if w.Code != http.StatusOK || body.Token == "" {
+		t.Fatalf("impersonate: %d %s", w.Code, w.Body.String())
+	}
+	return body.Token
+}
+
+func TestImpersonationActsAsUserAndIsAudited(t *testing.T) {
+	r := newTestRouter(t, nil)
+	adminToken := login(t, r, "admin1", "admin")
+	token := impersonate(t, r, adminToken, 1)
+
+	listID := createList(t, r, token)
+
+	// Oluşturulan liste kullanıcıya aittir; denetim kaydında admin impersonator olarak görünür
+	w := request(r, "GET", "/api/v1/admin/audit?action=list.create&target_id="+strconv.Itoa(listID), adminToken, "")
+	var events []struct {
+		ActorID        int `json:"actor_id"`
+		ImpersonatorID int `json:"impersonator_id"`
+	}
+	decode(t, w, &events)
+	if len(events) != 1 || events[0].ActorID != 1 || events[0].ImpersonatorID != 2 {
+		t.Fatalf("audit = %s", w.Body.String())
+	}
+
+	w = request(r, "GET", "/api/v1/admin/audit?action=impersonation.start&target_id=1", adminToken, "")
+	decode(t, w, &events)
+	if len(events) == 0 || events[0].ActorID != 2 {
+		t.Fatalf("impersonation.start audit = %s", w.Body.String())
+	}
+}
+
+func TestImpersonationCannotReachAdminRoutes(t *testing.T) {
+	r := newTestRouter(t, nil)
+	adminToken := login(t, r, "admin1", "admin")
+
+	// Başka bir admin hedef alınamaz; impersonation oturumu admin yetkisi taşıyamaz
+	w := request(r, "POST", "/api/v1/admin/users", adminToken, `{"username":"otheradmin","password":"secret","role":"admin"}`)
+	var other struct {
+		ID int `json:"id"`
+	}
+	decode(t, w, &other)
+	if w := request(r, "POST", "/api/v1/admin/users/"+strconv.Itoa(other.ID)+"/impersonate", adminToken, ""); w.Code != http.StatusForbidden {
+		t.Fatalf("impersonating an admin: %d %s", w.Code, w.Body.String())
+	}
+	if w := request(r, "POST", "/api/v1/admin/users/2/impersonate", adminToken, ""); w.Code == http.StatusOK {
+		t.Error("admin impersonated themselves")
+	}
+
+	token := impersonate(t, r, adminToken, 1)
+	for _, path := range []string{"/api/v1/admin/todolists", "/api/v1/admin/users"} {
+		if w := request(r, "GET", path, token, ""); w.Code != http.StatusForbidden {
+			t.Errorf("GET %s: %d", path, w.Code)
+		}
+	}
+	if w := request(r, "POST", "/api/v1/admin/users/3/impersonate", token, ""); w.Code != http.StatusForbidden {
+		t.Errorf("nested impersonation: %d", w.Code)
+	}
+
+	// Impersonator admin devre dışı bırakılınca adına açılmış oturum da geçersiz olur
+	userToken := impersonate(t, r, login(t, r, "otheradmin", "secret"), 1)
+	if w := request(r, "GET", "/api/v1/todolists", userToken, ""); w.Code != http.StatusOK {
+		t.Fatalf("impersonated request: %d", w.Code)
+	}
+	if w := request(r, "POST", "/api/v1/admin/users/"+strconv.Itoa(other.ID)+"/disable", adminToken, ""); w.Code != http.StatusOK {
+		t.Fatalf("disable: %d", w.Code)
+	}
+	if w := request(r, "GET", "/api/v1/todolists", userToken, ""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("request after impersonator was disabled: %d", w.Code)
+	}
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestBatchRoute(t *testing.T) {
+	r := newTestRouter(t, nil)
+	token := login(t, r, "user1", "1234")
+	listID := strconv.Itoa(createList(t, r, token))
+
+	w := request(r, "POST", "/api/v1/todolists/"+listID+"/items:batch", token,
+		`{"operations":[{"op":"create","content":"a"},{"op":"create","content":"b"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("batch: %d %s", w.Code, w.Body.String())
+	}
+
+	// Boş içerikli update atomik modda hiçbir işlemi uygulamadan 422 döner
+	w = request(r, "POST", "/api/v1/todolists/"+listID+"/items:batch", token,
+		`{"operations":[{"op":"create","content":"c"},{"op":"update","id":1,"content":""}]}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("invalid batch: %d %s", w.Code, w.Body.String())
+	}
+
+	// Yalnızca items:batch tanımlı; diğer işlemler ve eski /items/batch yolu 404 döner
+	for _, path := range []string{"/whatever", "/items:import", "/items/batch"} {
+		if w := request(r, "POST", "/api/v1/todolists/"+listID+path, token, `{}`); w.Code != http.StatusNotFound {
+			t.Fatalf("%s: %d", path, w.Code)
+		}
+	}
+
+	// Aynı seviyedeki diğer liste rotaları etkilenmez
+	if w := request(r, "POST", "/api/v1/todolists/"+listID+"/items", token, `{"content":"d"}`); w.Code != http.StatusCreated {
+		t.Fatalf("add item: %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"bytes"
+	"log/slog"
+	"priviatodolist/logging"
+	"strings"
+	"testing"
+)
+
+// Varsayılan logger'ı test süresince bir tampona yönlendirir
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf, "debug"))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+func TestAccessLogRedactsSecrets(t *testing.T) {
+	r := newTestRouter(t, nil)
+	token := login(t, r, "user1", "1234")
+	logs := captureLogs(t)
+
+	request(r, "GET", "/api/v1/calendar/feedsecret123", "", "")
+	request(r, "GET", "/api/v1/todolists?access_token="+token, token, "")
+	request(r, "POST", "/api/v1/login", "", `{"username":"user1","password":"wrongpass"}`)
+
+	out := logs.String()
+	if !strings.Contains(out, `"msg":"request"`) {
+		t.Fatalf("no access log written: %s", out)
+	}
+	for _, secret := range []string{"feedsecret123", token, "wrongpass"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %q:\n%s", secret, out)
+		}
+	}
+	if !strings.Contains(out, `"route":"/api/v1/calendar/:token"`) {
+		t.Errorf("route template missing:\n%s", out)
+	}
+}
@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// Metrik satırındaki değeri döner; satır yoksa 0
+func metricValue(t *testing.T, r http.Handler, series string) float64 {
+	t.Helper()
+	w := request(r, "GET", "/metrics", "", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("metrics: %d", w.Code)
+	}
+	m := regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(series) + ` (\S+)$`).FindStringSubmatch(w.Body.String())
+	if m == nil {
+		return 0
+	}
+	v, _ := strconv.ParseFloat(m[1], 64)
+	return v
+}
+
+func TestMetricsUseRouteTemplates(t *testing.T) {
+	r := newTestRouter(t, nil)
+	token := login(t, r, "user1", "1234")
+	listID := strconv.Itoa(createList(t, r, token))
+
+	series := `http_requests_total{method="GET",route="/api/v1/todolists/:id/items",status="200"}`
+	before := metricValue(t, r, series)
+	request(r, "GET", "/api/v1/todolists/"+listID+"/items", token, "")
+	if got := metricValue(t, r, series); got != before+1 {
+		t.Fatalf("%s = %v, want %v", series, got, before+1)
+	}
+
+	unmatched := `http_requests_total{method="GET",route="unmatched",status="404"}`
+	before = metricValue(t, r, unmatched)
+	request(r, "GET", "/no/such/path/"+listID, "", "")
+	if got := metricValue(t, r, unmatched); got != before+1 {
+		t.Fatalf("%s = %v, want %v", unmatched, got, before+1)
+	}
+}
+
+func TestLoginMetrics(t *testing.T) {
+	r := newTestRouter(t, nil)
+	failures := `todo_login_attempts_total{result="failure"}`
+	successes := `todo_login_attempts_total{result="success"}`
+	beforeFailures, beforeSuccesses := metricValue(t, r, failures), metricValue(t, r, successes)
+
+	request(r, "POST", "/api/v1/login", "", `{"username":"user2","password":"yanlış"}`)
+	login(t, r, "user2", "abcd")
+
+	if got := metricValue(t, r, failures); got != beforeFailures+1 {
+		t.Errorf("failures = %v, want %v", got, beforeFailures+1)
+	}
+	if got := metricValue(t, r, successes); got != beforeSuccesses+1 {
+		t.Errorf("successes = %v, want %v", got, beforeSuccesses+1)
+	}
+	if metricValue(t, r, "todo_sessions_active") < 1 {
+		t.Error("todo_sessions_active is not reported")
+	}
+}
@@ -0,0 +1,103 @@
+package routes
+
+import (
+	"net/http"
+	"priviatodolist/config"
+	"priviatodolist/repositories"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLoginRateLimit(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) {
+		cfg.LoginRateLimitRPS, cfg.LoginRateLimitBurst = 0.2, 2
+	})
+	body := `{"username":"nobody","password":"x"}`
+	t.Cleanup(func() { repositories.ResetLoginAttempts("nobody") })
+
+	for want := 1; want >= 0; want-- {
+		w := request(r, "POST", "/api/v1/login", "", body)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want 400", w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Remaining"); got != strconv.Itoa(want) {
+			t.Fatalf("RateLimit-Remaining = %q, want %d", got, want)
+		}
+	}
+	w := request(r, "POST", "/api/v1/login", "", body)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("Retry-After") != "5" {
+		t.Fatalf("headers = %v", w.Header())
+	}
+	// Login sınırı diğer uç noktaları etkilemez
+	if w := request(r, "GET", "/healthz", "", ""); w.Code != http.StatusOK {
+		t.Fatalf("healthz = %d", w.Code)
+	}
+}
+
+func TestLoginLockoutRetryAfter(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) {
+		cfg.LoginLockoutThreshold = 2
+		cfg.LoginLockoutBase.Duration = time.Minute
+		cfg.LoginLockoutMax.Duration = time.Hour
+	})
+	t.Cleanup(func() { repositories.ResetLoginAttempts("user2") })
+	wrong := `{"username":"user2","password":"wrong"}`
+
+	request(r, "POST", "/api/v1/login", "", wrong)
+	request(r, "POST", "/api/v1/login", "", wrong)
+
+	w := request(r, "POST", "/api/v1/login", "", `{"username":"user2","password":"abcd"}`)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Fatalf("Retry-After = %q, want 60", got)
+	}
+
+	// Kilit kullanıcı adına bağlıdır; başka kullanıcılar giriş yapabilir
+	login(t, r, "user1", "1234")
+}
+
+// Güvenilen proxy yoksa X-Forwarded-For yok sayılır; sahte başlıkla IP sınırı sıfırlanamaz
+// ve oturumlara bağlantının adresi yazılır
+func TestForwardedForRequiresTrustedProxy(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) {
+		cfg.LoginRateLimitRPS, cfg.LoginRateLimitBurst = 0.2, 2
+	})
+	body := `{"username":"user1","password":"1234"}`
+	for i := 0; i < 2; i++ {
+		w := request(r, "POST", "/api/v1/login", "", body, "X-Forwarded-For", "203.0.113."+strconv.Itoa(i+1))
+		if w.Code != http.StatusOK {
+			t.Fatalf("login %d: %d %s", i, w.Code, w.Body)
+		}
+	}
+	if w := request(r, "POST", "/api/v1/login", "", body, "X-Forwarded-For", "203.0.113.99"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("forged X-Forwarded-For reset the limiter: status = %d", w.Code)
+	}
+
+	sessions, _ := repositories.GetSessionsByUserID(1)
+	if len(sessions) == 0 {
+		t.Fatal("no sessions")
+	}
+	for _, s := range sessions {
+		if s.IP == "203.0.113.1" || s.IP == "203.0.113.2" {
+			t.Fatalf("session IP taken from a forged header: %s", s.IP)
+		}
+	}
+
+	// Güvenilen proxy'nin ilettiği istemci adresleri ayrı ayrı sınırlanır
+	r = newTestRouter(t, func(cfg *config.Config) {
+		cfg.LoginRateLimitRPS, cfg.LoginRateLimitBurst = 0.2, 2
+		cfg.TrustedProxies = []string{"192.0.2.0/24"}
+	})
+	for i := 0; i < 3; i++ {
+		w := request(r, "POST", "/api/v1/login", "", body, "X-Forwarded-For", "203.0.113."+strconv.Itoa(i+1))
+		if w.Code != http.StatusOK {
+			t.Fatalf("proxied login %d: %d %s", i, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"priviatodolist/config"
+	"priviatodolist/utils"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Panic'ler logger, metrik ve güvenlik başlığı middleware'larının içinde yakalanır
+func TestPanicIsRecoveredInsideMiddlewareChain(t *testing.T) {
+	r := newTestRouter(t, nil)
+	r.GET("/test/panic", func(c *gin.Context) { panic("boom") })
+	series := `http_requests_total{method="GET",route="/test/panic",status="500"}`
+	before := metricValue(t, r, series)
+	logs := captureLogs(t)
+
+	w := request(r, "GET", "/test/panic", "", "", "X-Request-ID", "req-recover")
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	if w.Header().Get("X-Request-ID") != "req-recover" || w.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Fatalf("headers = %v", w.Header())
+	}
+	if !strings.Contains(w.Body.String(), `"request_id":"req-recover"`) {
+		t.Fatalf("body = %s", w.Body)
+	}
+
+	out := logs.String()
+	if !strings.Contains(out, `"msg":"panic recovered"`) || !strings.Contains(out, `"level":"ERROR","msg":"request"`) {
+		t.Fatalf("log = %s", out)
+	}
+	if strings.Count(out, `"request_id":"req-recover"`) != 2 {
+		t.Fatalf("panic and access log should carry the request ID: %s", out)
+	}
+	if got := metricValue(t, r, series); got != before+1 {
+		t.Fatalf("%s = %v, want %v", series, got, before+1)
+	}
+}
+
+func TestPanicAfterStreamingStartedEndsResponse(t *testing.T) {
+	r := newTestRouter(t, nil)
+	r.GET("/test/partial", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		c.Writer.Flush()
+		panic("late boom")
+	})
+	captureLogs(t)
+
+	w := request(r, "GET", "/test/partial", "", "")
+	if w.Code != http.StatusOK || w.Body.String() != "partial" {
+		t.Fatalf("response = %d %q, want the partial 200", w.Code, w.Body)
+	}
+}
+
+// Middleware'ların döndüğü hatalar da standart hata gövdesini kullanır
+func TestMiddlewareErrorsUseStandardBody(t *testing.T) {
+	r := newTestRouter(t, nil)
+	token := login(t, r, "user1", "1234")
+	limited := newTestRouter(t, func(cfg *config.Config) {
+		cfg.RateLimitIPRPS, cfg.RateLimitIPBurst = 0.1, 1
+	})
+	request(limited, "GET", "/api/v1/todolists", "", "")
+
+	tests := []struct {
+		name   string
+		w      *httptest.ResponseRecorder
+		status int
+	}{
+		{"no token", request(r, "GET", "/api/v1/todolists", "", "", "X-Request-ID", "req-std"), http.StatusUnauthorized},
+		{"invalid token", request(r, "GET", "/api/v1/todolists", "not-a-jwt", "", "X-Request-ID", "req-std"), http.StatusUnauthorized},
+		{"missing permission", request(r, "GET", "/api/v1/admin/audit", token, "", "X-Request-ID", "req-std"), http.StatusForbidden},
+		{"rate limited", request(limited, "GET", "/api/v1/todolists", "", "", "X-Request-ID", "req-std"), http.StatusTooManyRequests},
+	}
+	for _, tt := range tests {
+		var body utils.ErrorResponse
+		if tt.w.Code != tt.status || json.Unmarshal(tt.w.Body.Bytes(), &body) != nil {
+			t.Fatalf("%s: %d %s", tt.name, tt.w.Code, tt.w.Body)
+		}
+		if body.Status != tt.status || body.Message == "" || body.RequestID != "req-std" {
+			t.Errorf("%s: body = %s", tt.name, tt.w.Body)
+		}
+	}
+}
@@ -1,40 +1,143 @@
 package routes
 
 import (
+	"priviatodolist/config"
 	"priviatodolist/controllers"
+	"priviatodolist/jwtkeys"
 	"priviatodolist/middleware"
+	"priviatodolist/policy"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRouter() *gin.Engine {
-	r := gin.Default()
+// SetupRouter rotaları cfg'deki ayarlarla kurar; token'lar keys ile imzalanır ve doğrulanır
+func SetupRouter(cfg *config.Config, keys *jwtkeys.KeySet) *gin.Engine {
+	r := gin.New()
+	// X-Forwarded-For yalnızca güvenilen proxy'lerden okunur; liste boşsa ClientIP bağlantının adresidir.
+	// Adresler config.Validate'te doğrulandığı için hata beklenmez.
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		panic(err)
+	}
+	// Panic'ler tüm rotalarda (login, swagger, sağlık uç noktaları dahil) ve sonraki tüm middleware'larda burada yakalanır
+	r.Use(middleware.GlobalErrorHandler())
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Tracing())
+	r.Use(middleware.RequestLogger())
+	r.Use(middleware.Metrics())
+	r.Use(middleware.SecurityHeaders(cfg.HSTSMaxAge.Duration))
+	r.Use(middleware.CORS(cfg.CORSOrigins, cfg.CORSMethods, cfg.CORSAllowCredentials, cfg.CORSMaxAge.Duration))
+	r.Use(middleware.BodyLimit(cfg.MaxBodyBytes))
+
+	// Orkestratör için sağlık ve sürüm uç noktaları (kimlik doğrulama gerektirmez)
+	r.GET("/healthz", controllers.Healthz)
+	r.GET("/readyz", controllers.Readyz(cfg))
+	r.GET("/version", controllers.Version)
+	r.GET("/metrics", controllers.Metrics)
+
+	// Diğer servislerin token'ları doğrulayabilmesi için açık anahtarlar
+	r.GET("/.well-known/jwks.json", controllers.JWKS(keys))
 
 	// Swagger
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// IP ve kullanıcı başına istek sınırları; login için ayrıca daha sıkı bir IP sınırı uygulanır
+	ipLimit := middleware.RateLimit(cfg.RateLimitIPRPS, cfg.RateLimitIPBurst, middleware.ClientIPKey)
+	userLimit := middleware.RateLimit(cfg.RateLimitUserRPS, cfg.RateLimitUserBurst, middleware.UserKey)
+	loginLimit := middleware.RateLimit(cfg.LoginRateLimitRPS, cfg.LoginRateLimitBurst, middleware.ClientIPKey)
+
 	// API v1 grubu (JWT korumalı)
 	api := r.Group("/api/v1")
-	api.POST("/login", controllers.Login)
-	api.Use(middleware.JWTAuthMiddleware())
-	api.Use(middleware.GlobalErrorHandler())
+	api.Use(ipLimit)
+	// Login Idempotency kapsamında değildir: saklanan yanıt geçerli token'lar içerirdi ve
+	// tekrar edilen giriş yalnızca yeni bir oturum açar
+	api.POST("/login", loginLimit, controllers.Login(cfg, keys))
+	api.GET("/stream", middleware.TokenFromQuery("access_token"), middleware.JWTAuthMiddleware(keys), userLimit, controllers.StreamEvents)
+	api.GET("/calendar/:token", controllers.GetCalendarFeed)
+	api.Use(middleware.JWTAuthMiddleware(keys))
+	api.Use(userLimit)
+	api.Use(middleware.Idempotency(cfg.IdempotencyTTL.Duration))
 	{
 		api.GET("/todolists", controllers.GetMyTodoLists)
 		api.POST("/todolists", controllers.CreateTodoList)
+		api.POST("/todolists/import", controllers.ImportTodoList)
+		api.GET("/todolists/:id/export", controllers.ExportTodoList)
 		api.GET("/todolists/:id/items", controllers.GetTodoItems)
 		api.POST("/todolists/:id/items", controllers.AddTodoItem)
+		api.POST("/todolists/:id/items/ics", controllers.ImportCalendarItems)
+		// Gin ":" içeren parçaları parametre saydığı için items:batch bu rotadan karşılanır
+		api.POST("/todolists/:id/:action", controllers.TodoListAction)
 		api.PUT("/items/:id", controllers.UpdateTodoItem)
 		api.DELETE("/items/:id", controllers.DeleteTodoItem)
 		api.PUT("/todolists/:id", controllers.UpdateTodoList)
 		api.DELETE("/todolists/:id", controllers.DeleteTodoList)
+		api.GET("/items/:id/history", controllers.GetTodoItemHistory)
+		api.POST("/items/:id/revert", controllers.RevertTodoItem)
+		api.GET("/todolists/:id/history", controllers.GetTodoListHistory)
+		api.GET("/trash", controllers.GetTrash)
+		api.POST("/todolists/:id/restore", controllers.RestoreTodoList)
+		api.POST("/items/:id/restore", controllers.RestoreTodoItem)
+		api.POST("/me/export", controllers.ExportMyData)
+		api.DELETE("/me", controllers.DeleteMyAccount)
+		api.POST("/me/calendar", controllers.CreateCalendarToken)
+		api.DELETE("/me/calendar", controllers.RevokeCalendarToken)
+		api.GET("/sync", controllers.GetSyncChanges)
+		api.POST("/sync", controllers.PushSyncChanges)
 
-		adminOnly := api.Group("/admin")
-		adminOnly.Use(middleware.AdminOnly())
+		webhooks := api.Group("/webhooks")
+		webhooks.Use(middleware.RequirePermission(policy.WebhooksManageOwn))
 		{
-			adminOnly.GET("/todolists", controllers.GetTodoListsForAdmin)
-			adminOnly.GET("/todolists/:id/items", controllers.GetAllTodoItemsForAdmin)
+			webhooks.GET("", controllers.GetMyWebhooks)
+			webhooks.POST("", controllers.CreateWebhook)
+			webhooks.DELETE("/:id", controllers.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", controllers.GetWebhookDeliveries)
+			webhooks.POST("/:id/test", controllers.TestWebhook)
+		}
+
+		// Impersonation oturumları admin rotalarına erişemez
+		admin := api.Group("/admin")
+		admin.Use(middleware.DenyImpersonation())
+		{
+			admin.GET("/todolists", middleware.RequirePermission(policy.ListsReadAny), controllers.GetTodoListsForAdmin)
+			admin.GET("/todolists/:id/items", middleware.RequirePermission(policy.ItemsReadAny), controllers.GetAllTodoItemsForAdmin)
+
+			audit := admin.Group("/audit")
+			audit.Use(middleware.RequirePermission(policy.AuditRead))
+			{
+				audit.GET("", controllers.GetAuditEvents)
+				audit.GET("/export", controllers.ExportAuditEvents)
+			}
+
+			events := admin.Group("/events")
+			events.Use(middleware.RequirePermission(policy.EventsManage))
+			{
+				events.GET("", controllers.GetEvents)
+				events.POST("/replay", controllers.ReplayEvents)
+			}
+
+			trash := admin.Group("/trash")
+			trash.Use(middleware.RequirePermission(policy.TrashPurge))
+			{
+				trash.DELETE("", controllers.PurgeTrash)
+				trash.DELETE("/todolists/:id", controllers.PurgeTodoList)
+				trash.DELETE("/items/:id", controllers.PurgeTodoItem)
+			}
+
+			users := admin.Group("/users")
+			users.Use(middleware.RequirePermission(policy.UsersManage))
+			{
+				users.GET("", controllers.GetUsersForAdmin)
+				users.POST("", controllers.CreateUser)
+				users.GET("/:id", controllers.GetUserForAdmin)
+				users.POST("/:id/disable", controllers.DisableUser)
+				users.POST("/:id/enable", controllers.EnableUser)
+				users.PUT("/:id/role", controllers.ChangeUserRole)
+				users.POST("/:id/password", controllers.ResetUserPassword)
+				users.GET("/:id/todolists", controllers.GetUserTodoListsForAdmin)
+				users.GET("/:id/sessions", controllers.GetUserSessionsForAdmin)
+				users.POST("/:id/impersonate", middleware.RequirePermission(policy.UsersImpersonate), controllers.ImpersonateUser(cfg, keys))
+			}
 		}
 	}
 
@@ -0,0 +1,95 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"priviatodolist/config"
+	"priviatodolist/jwtkeys"
+	"priviatodolist/repositories"
+	"priviatodolist/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	if err := repositories.BootstrapEventLog(); err != nil {
+		panic(err)
+	}
+	stopStreams := services.StartStreamBroker()
+	code := m.Run()
+	stopStreams()
+	os.Exit(code)
+}
+
+// Varsayılan ayarlarla router kurar; testlerin ayarları değiştirmesi için cfg döner
+func newTestRouter(t *testing.T, configure func(cfg *config.Config)) *gin.Engine {
+	t.Helper()
+	cfg := config.Default()
+	// Testler aynı IP'den çok sayıda istek yapar
+	cfg.RateLimitIPRPS, cfg.RateLimitUserRPS, cfg.LoginRateLimitRPS = 0, 0, 0
+	if configure != nil {
+		configure(cfg)
+	}
+	keys, err := jwtkeys.New(cfg.JWTAlgorithm, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return SetupRouter(cfg, keys)
+}
+
+func request(r http.Handler, method, path, token, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func login(t *testing.T, r http.Handler, username, password string) string {
+	t.Helper()
+	w := request(r, "POST", "/api/v1/login", "", `{"username":"`+username+`","password":"`+password+`"}`)
+	var body struct {
+		Token string `json:"token"`
+	}
+	if w.Code != http.StatusOK || json.Unmarshal(w.Body.Bytes(), &body) != nil || body.Token == "" {
+		t.Fatalf("login %s: %d %s", username, w.Code, w.Body.String())
+	}
+	return body.Token
+}
+
+// Yanıt gövdesini v'ye çözer
+func decode(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+}
+
+// Kullanıcı için yeni liste oluşturur ve ID'sini döner
+func createList(t *testing.T, r http.Handler, token string) int {
+	t.Helper()
+	w := request(r, "POST", "/api/v1/todolists", token, `{"name":"Router test"}`)
+	if w.Code != http.StatusCreated && w.Code != http.StatusOK {
+		t.Fatalf("create list: %d %s", w.Code, w.Body.String())
+	}
+	var list struct {
+		ID int `json:"id"`
+	}
+	decode(t, w, &list)
+	return list.ID
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"priviatodolist/config"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimit(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) { cfg.MaxBodyBytes = 64 })
+	token := login(t, r, "user1", "1234")
+
+	large := `{"name":"` + strings.Repeat("a", 100) + `"}`
+	if w := request(r, "POST", "/api/v1/todolists", token, large); w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("large body: %d", w.Code)
+	}
+
+	// Content-Length bilinmeyen gövde okunurken limitte kesilir
+	req := httptest.NewRequest("POST", "/api/v1/todolists", io.MultiReader(strings.NewReader(large)))
+	req.ContentLength = -1
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Code == http.StatusCreated || w.Code == http.StatusOK {
+		t.Fatalf("chunked large body accepted: %d", w.Code)
+	}
+
+	if w := request(r, "POST", "/api/v1/todolists", token, `{"name":"Kısa"}`); w.Code != http.StatusCreated {
+		t.Fatalf("small body: %d %s", w.Code, w.Body.String())
+	}
+}
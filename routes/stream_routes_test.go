@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"priviatodolist/repositories"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseMessage struct {
+	id    string
+	event string
+	data  string
+}
+
+// Stream'i açar ve gelen mesajları kanala yazar; bağlantı kapanınca kanal kapanır
+func openStream(t *testing.T, server *httptest.Server, token, lastEventID string) <-chan sseMessage {
+	t.Helper()
+	req, _ := http.NewRequest("GET", server.URL+"/api/v1/stream", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		t.Fatalf("stream: %d", resp.StatusCode)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	messages := make(chan sseMessage, 16)
+	go func() {
+		defer close(messages)
+		scanner := bufio.NewScanner(resp.Body)
+		var msg sseMessage
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "id: "):
+				msg.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				msg.event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				msg.data = strings.TrimPrefix(line, "data: ")
+			case line == "" && msg.id != "":
+				messages <- msg
+				msg = sseMessage{}
+			}
+		}
+	}()
+	return messages
+}
+
+// Cleanup sırası: önce stream gövdeleri kapanır, sonra sunucu
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(newTestRouter(t, nil))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func nextMessage(t *testing.T, messages <-chan sseMessage) sseMessage {
+	t.Helper()
+	select {
+	case msg, ok := <-messages:
+		if !ok {
+			t.Fatal("stream closed")
+		}
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a stream message")
+	}
+	return sseMessage{}
+}
+
+func TestStreamResumesFromLastEventID(t *testing.T) {
+	server := newTestServer(t)
+	token := login(t, server.Config.Handler, "user1", "1234")
+	listID := strconv.Itoa(createList(t, server.Config.Handler, token))
+
+	lastSeq := repositories.GetLastEventSeq()
+	request(server.Config.Handler, "POST", "/api/v1/todolists/"+listID+"/items", token, `{"content":"kaçırılan"}`)
+
+	// Bağlantı yokken eklenen item Last-Event-ID ile alınır, ardından canlı event'ler gelir
+	messages := openStream(t, server, token, strconv.FormatInt(lastSeq, 10))
+	missed := nextMessage(t, messages)
+	if missed.id != strconv.FormatInt(lastSeq+1, 10) || missed.event != "item.created" || !strings.Contains(missed.data, "kaçırılan") {
+		t.Fatalf("missed event = %+v", missed)
+	}
+
+	request(server.Config.Handler, "POST", "/api/v1/todolists/"+listID+"/items", token, `{"content":"canlı"}`)
+	live := nextMessage(t, messages)
+	if live.id != strconv.FormatInt(lastSeq+2, 10) || !strings.Contains(live.data, "canlı") {
+		t.Fatalf("live event = %+v", live)
+	}
+}
+
+func TestStreamClosedWhenUserDisabled(t *testing.T) {
+	server := newTestServer(t)
+	r := server.Config.Handler
+	adminToken := login(t, r, "admin1", "admin")
+
+	w := request(r, "POST", "/api/v1/admin/users", adminToken, `{"username":"streamuser","password":"secret","role":"user"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create user: %d %s", w.Code, w.Body.String())
+	}
+	var user struct {
+		ID int `json:"id"`
+	}
+	decode(t, w, &user)
+	token := login(t, r, "streamuser", "secret")
+	messages := openStream(t, server, token, "")
+
+	if w := request(r, "POST", "/api/v1/admin/users/"+strconv.Itoa(user.ID)+"/disable", adminToken, ""); w.Code != http.StatusOK {
+		t.Fatalf("disable: %d %s", w.Code, w.Body.String())
+	}
+	select {
+	case _, ok := <-messages:
+		if ok {
+			t.Fatal("received a message after the user was disabled")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream stayed open after the user was disabled")
+	}
+
+	// Yeniden bağlanma denemesi reddedilir
+	req, _ := http.NewRequest("GET", server.URL+"/api/v1/stream", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("reconnect: %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,148 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"priviatodolist/tracing"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Attributes   []struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	} `json:"attributes"`
+}
+
+func (s exportedSpan) attr(key string) string {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value.StringValue
+		}
+	}
+	return ""
+}
+
+// Yerel OTLP toplayıcısına span gönderen bir exporter kurar; dönen fonksiyon
+// exporter'ı durdurup toplanan span'ları verir
+func collectSpans(t *testing.T) func() []exportedSpan {
+	t.Helper()
+	var (
+		mu    sync.Mutex
+		spans []exportedSpan
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rs := range body.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	stop := sync.OnceFunc(tracing.Setup(tracing.ExporterOTLP, server.URL, "todo-test"))
+	t.Cleanup(stop)
+	return func() []exportedSpan {
+		stop()
+		mu.Lock()
+		defer mu.Unlock()
+		return spans
+	}
+}
+
+func TestTracePropagation(t *testing.T) {
+	r := newTestRouter(t, nil)
+	token := login(t, r, "user1", "1234")
+	collected := collectSpans(t)
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	const parentID = "00f067aa0ba902b7"
+	w := request(r, "GET", "/api/v1/todolists", token, "",
+		"traceparent", "00-"+traceID+"-"+parentID+"-01",
+		"X-Request-ID", "req-trace-1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	if got := w.Header().Get("X-Request-ID"); got != "req-trace-1" {
+		t.Fatalf("X-Request-ID = %q", got)
+	}
+
+	var server *exportedSpan
+	var children []exportedSpan
+	for _, s := range collected() {
+		switch {
+		case s.Name == "GET /api/v1/todolists":
+			server = &s
+		case s.TraceID == traceID:
+			children = append(children, s)
+		}
+	}
+	if server == nil {
+		t.Fatal("server span was not exported")
+	}
+	if server.TraceID != traceID || server.ParentSpanID != parentID {
+		t.Errorf("server span trace=%s parent=%s, want %s/%s", server.TraceID, server.ParentSpanID, traceID, parentID)
+	}
+	if server.attr("request_id") != "req-trace-1" || server.attr("http.route") != "/api/v1/todolists" {
+		t.Errorf("server span attributes = %+v", server.Attributes)
+	}
+	if len(children) == 0 {
+		t.Fatal("no service spans joined the incoming trace")
+	}
+	spans := map[string]exportedSpan{}
+	for _, s := range children {
+		if !strings.HasPrefix(s.Name, "services.") && !strings.HasPrefix(s.Name, "repositories.") {
+			t.Errorf("unexpected span %q in trace", s.Name)
+		}
+		spans[s.Name] = s
+	}
+
+	// Okuma çağrıları da servis span'ının altında izlenir
+	service, ok := spans["services.GetMyTodoLists"]
+	if !ok || service.ParentSpanID != server.SpanID {
+		t.Fatalf("service span = %+v, want a child of the server span", service)
+	}
+	if read, ok := spans["repositories.GetTodoListsByUserID"]; !ok || read.ParentSpanID != service.SpanID {
+		t.Errorf("repository read span = %+v, want a child of the service span", read)
+	}
+}
+
+func TestInvalidTraceParentStartsNewTrace(t *testing.T) {
+	r := newTestRouter(t, nil)
+	collected := collectSpans(t)
+
+	request(r, "GET", "/healthz", "", "", "traceparent", "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+
+	for _, s := range collected() {
+		if s.Name != "GET /healthz" {
+			continue
+		}
+		if s.ParentSpanID != "" || s.TraceID == strings.Repeat("0", 32) {
+			t.Fatalf("span = %+v, want a new root trace", s)
+		}
+		if s.attr("request_id") == "" {
+			t.Error("generated request ID missing from span")
+		}
+		return
+	}
+	t.Fatal("server span was not exported")
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"reflect"
+)
+
+// Denetim kaydı hedef türleri
+const (
+	AuditTargetList = "list"
+	AuditTargetItem = "item"
+	AuditTargetUser = "user"
+)
+
+// Arka plan işleri için sistem kullanıcısı
+var systemActor = models.Actor{Username: "system"}
+
+// Denetim kaydı oluşturur. before/after değişiklikten önceki ve sonraki kopyalardır (nil olabilir).
+// Kayıt hatası isteği başarısız kılmaz.
+func RecordAudit(ctx context.Context, actor models.Actor, action, targetType string, targetID int, before, after interface{}) {
+	event := &models.AuditEvent{
+		ActorID:        actor.UserID,
+		ActorUsername:  actor.Username,
+		ImpersonatorID: actor.ImpersonatorID,
+		Action:         action,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		RequestID:      actor.RequestID,
+		IP:             actor.IP,
+	}
+	event.Before = marshalAudit(before)
+	event.After = marshalAudit(after)
+	event.Changes = auditChanges(event.Before, event.After)
+
+	span := startRepositorySpan(ctx, "AppendAuditEvent")
+	span.SetAttr("audit.action", action)
+	defer span.End()
+	if _, err := repositories.AppendAuditEvent(event); err != nil {
+		span.SetError(err)
+		slog.Error("recording audit event failed", "action", action, "request_id", actor.RequestID, "error", err)
+	}
+}
+
+func marshalAudit(v interface{}) json.RawMessage {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("marshalling audit snapshot failed", "error", err)
+		return nil
+	}
+	return data
+}
+
+// Önceki ve sonraki kopyalar arasındaki üst düzey alan farkları
+func auditChanges(before, after json.RawMessage) map[string]models.AuditChange {
+	if before == nil || after == nil {
+		return nil
+	}
+	var b, a map[string]interface{}
+	if json.Unmarshal(before, &b) != nil || json.Unmarshal(after, &a) != nil {
+		return nil
+	}
+
+	changes := map[string]models.AuditChange{}
+	for key, to := range a {
+		if from := b[key]; !reflect.DeepEqual(from, to) {
+			changes[key] = models.AuditChange{From: from, To: to}
+		}
+	}
+	for key, from := range b {
+		if _, ok := a[key]; !ok {
+			changes[key] = models.AuditChange{From: from, To: nil}
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+// Denetim kaydı için listenin item'lar olmadan kopyası
+func listSnapshot(list *models.TodoList) *models.TodoList {
+	if list == nil {
+		return nil
+	}
+	snapshot := *list
+	snapshot.Items = nil
+	return &snapshot
+}
+
+// Denetim kaydı için item'ın kopyası
+func itemSnapshot(item *models.TodoItem) *models.TodoItem {
+	if item == nil {
+		return nil
+	}
+	snapshot := *item
+	return &snapshot
+}
+
+// Denetim kaydı için kullanıcının kopyası (şifre hash'i JSON'a yazılmaz)
+func userSnapshot(user *models.User) *models.User {
+	if user == nil {
+		return nil
+	}
+	snapshot := *user
+	return &snapshot
+}
+
+// Admin için: denetim kayıtlarını filtreleyerek getir
+func GetAuditEvents(ctx context.Context, actor models.Actor, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	ctx, span := startSpan(ctx, "GetAuditEvents")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.AuditRead) {
+		return nil, errors.New("forbidden")
+	}
+	return repositories.QueryAuditEvents(filter)
+}
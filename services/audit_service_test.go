@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"testing"
+	"time"
+)
+
+func TestAuditRecordsChangesAndFilters(t *testing.T) {
+	start := time.Now()
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Önce")
+	if _, err := UpdateItem(context.Background(), item.ID, testUser, &models.TodoItem{Content: "Sonra"}); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := GetAuditEvents(context.Background(), testAdmin, models.AuditFilter{Action: "item.update", TargetID: item.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d item.update events, want 1", len(events))
+	}
+	change, ok := events[0].Changes["content"]
+	if !ok || change.From != "Önce" || change.To != "Sonra" {
+		t.Fatalf("content change = %+v", events[0].Changes)
+	}
+	if events[0].ActorID != testUser.UserID {
+		t.Fatalf("actor = %d", events[0].ActorID)
+	}
+
+	// "item." öneki tüm item işlemleriyle eşleşir
+	prefixed, _ := GetAuditEvents(context.Background(), testAdmin, models.AuditFilter{Action: "item.", TargetType: AuditTargetItem, TargetID: item.ID})
+	if len(prefixed) != 2 {
+		t.Fatalf("item. prefix matched %d events, want create and update", len(prefixed))
+	}
+	limited, _ := GetAuditEvents(context.Background(), testAdmin, models.AuditFilter{ActorID: testUser.UserID, From: start, Limit: 1})
+	if len(limited) != 1 || limited[0].Action != "list.create" {
+		t.Fatalf("limit/from filter returned %+v", limited)
+	}
+	future, _ := GetAuditEvents(context.Background(), testAdmin, models.AuditFilter{From: time.Now().Add(time.Hour)})
+	if len(future) != 0 {
+		t.Fatalf("from filter returned %d future events", len(future))
+	}
+
+	if _, err := GetAuditEvents(context.Background(), testUser, models.AuditFilter{}); err == nil || err.Error() != "forbidden" {
+		t.Fatalf("user audit read: err = %v", err)
+	}
+}
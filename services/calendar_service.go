@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"priviatodolist/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const icalTimeFormat = "20060102T150405Z"
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Kullanıcı için yeni takvim token'ı oluşturur; varsa eski token geçersiz olur.
+// Token kalıcı erişim verdiği için impersonation oturumlarında oluşturulamaz.
+func CreateCalendarToken(ctx context.Context, actor models.Actor) (string, error) {
+	ctx, span := startSpan(ctx, "CreateCalendarToken")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.ItemsReadOwn) || actor.ImpersonatorID != 0 {
+		return "", errors.New("forbidden")
+	}
+	user, err := repositories.GetUserByID(actor.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	token := utils.RandomToken(32)
+	user.CalendarTokenHash = hashCalendarToken(token)
+	if _, err := repositories.UpdateUser(user.ID, user); err != nil {
+		return "", err
+	}
+
+	RecordAudit(ctx, actor, "calendar.token.create", AuditTargetUser, user.ID, nil, nil)
+	return token, nil
+}
+
+// Kullanıcının takvim token'ını iptal eder
+func RevokeCalendarToken(ctx context.Context, actor models.Actor) error {
+	ctx, span := startSpan(ctx, "RevokeCalendarToken")
+	defer span.End()
+
+	if actor.ImpersonatorID != 0 {
+		return errors.New("forbidden")
+	}
+	user, err := repositories.GetUserByID(actor.UserID)
+	if err != nil {
+		return err
+	}
+	if user.CalendarTokenHash == "" {
+		return errors.New("calendar token not found")
+	}
+
+	user.CalendarTokenHash = ""
+	if _, err := repositories.UpdateUser(user.ID, user); err != nil {
+		return err
+	}
+
+	RecordAudit(ctx, actor, "calendar.token.revoke", AuditTargetUser, user.ID, nil, nil)
+	return nil
+}
+
+// Token sahibinin aktif listelerindeki aktif item'ları VTODO olarak içeren iCalendar dosyası üretir
+func GetCalendarFeed(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, errors.New("calendar not found")
+	}
+	user, err := repositories.GetUserByCalendarTokenHash(hashCalendarToken(token))
+	if err != nil || user.Disabled || !policy.HasPermission(user.Role, policy.ItemsReadOwn) {
+		return nil, errors.New("calendar not found")
+	}
+	lists, err := getTodoListsByUserID(ctx, user.ID, false)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].ID < lists[j].ID })
+
+	now := time.Now().UTC().Format(icalTimeFormat)
+	w := &utils.ICalWriter{}
+	w.Line("BEGIN", "VCALENDAR")
+	w.Line("VERSION", "2.0")
+	w.Line("PRODID", "-//PriviaTodoList//TR")
+	w.Line("CALSCALE", "GREGORIAN")
+	w.Text("X-WR-CALNAME", "PriviaTodoList - "+user.Username)
+	for _, list := range lists {
+		items := list.Items
+		sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+		for _, item := range items {
+			w.Line("BEGIN", "VTODO")
+			w.Line("UID", "item-"+strconv.Itoa(item.ID)+"@priviatodolist")
+			w.Line("DTSTAMP", now)
+			w.Line("CREATED", item.CreatedAt.UTC().Format(icalTimeFormat))
+			w.Line("LAST-MODIFIED", item.UpdatedAt.UTC().Format(icalTimeFormat))
+			w.Text("SUMMARY", item.Content)
+			w.Text("CATEGORIES", list.Name)
+			if item.DueDate != nil {
+				w.Line("DUE", item.DueDate.UTC().Format(icalTimeFormat))
+			}
+			if item.IsDone {
+				w.Line("STATUS", "COMPLETED")
+				w.Line("COMPLETED", item.UpdatedAt.UTC().Format(icalTimeFormat))
+			} else {
+				w.Line("STATUS", "NEEDS-ACTION")
+			}
+			w.Line("END", "VTODO")
+		}
+	}
+	w.Line("END", "VCALENDAR")
+	return w.Bytes(), nil
+}
+
+// iCalendar dosyasındaki VTODO'ları listeye item olarak ekler.
+// Item'lar listeye elle eklemeyle aynı kurallarla önce doğrulanır; bir sorun varsa hiçbiri eklenmez.
+func ImportCalendarItems(ctx context.Context, listID int, actor models.Actor, data []byte) ([]*models.TodoItem, []models.ImportProblem, error) {
+	ctx, span := startSpan(ctx, "ImportCalendarItems")
+	defer span.End()
+
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil || list.DeletedAt != nil {
+		return nil, nil, errors.New("list not found")
+	}
+	if !canAccessList(ctx, actor, listID, policy.ResourceItems, policy.ActionWrite) {
+		return nil, nil, errors.New("unauthorized")
+	}
+
+	todos, err := utils.ParseICalComponents(data, "VTODO")
+	if err != nil {
+		return nil, nil, errors.New("invalid icalendar")
+	}
+	if len(todos) > MaxImportItems {
+		return nil, nil, errors.New("too many items")
+	}
+
+	var items []*models.TodoItem
+	var problems []models.ImportProblem
+	for i, props := range todos {
+		item, err := calendarItem(props)
+		if err == nil {
+			err = ValidateItem(item)
+		}
+		if err != nil {
+			problems = append(problems, models.ImportProblem{Line: i + 1, Error: err.Error()})
+			continue
+		}
+		items = append(items, item)
+	}
+	if len(problems) > 0 {
+		return nil, problems, errors.New("invalid import")
+	}
+
+	created := []*models.TodoItem{}
+	for _, item := range items {
+		c, err := AddItemToList(ctx, listID, actor, item)
+		if err != nil {
+			return nil, nil, err
+		}
+		created = append(created, c)
+	}
+	return created, nil, nil
+}
+
+// VTODO özelliklerinden item oluşturur: SUMMARY -> içerik, STATUS/COMPLETED -> tamamlanma, DUE -> bitiş tarihi
+func calendarItem(props []utils.ICalProperty) (*models.TodoItem, error) {
+	item := &models.TodoItem{}
+	for _, prop := range props {
+		switch prop.Name {
+		case "SUMMARY":
+			item.Content = utils.ICalUnescape(prop.Value)
+		case "STATUS":
+			item.IsDone = strings.EqualFold(prop.Value, "COMPLETED")
+		case "COMPLETED":
+			item.IsDone = true
+		case "DUE":
+			due, err := parseICalTime(prop)
+			if err != nil {
+				return nil, errors.New("invalid DUE value")
+			}
+			item.DueDate = &due
+		}
+	}
+	return item, nil
+}
+
+// DATE, UTC, TZID'li ve yerel (floating) zaman biçimlerini destekler; floating zamanlar UTC kabul edilir
+func parseICalTime(prop utils.ICalProperty) (time.Time, error) {
+	value := strings.TrimSpace(prop.Value)
+	if prop.Params["VALUE"] == "DATE" || len(value) == 8 {
+		return time.Parse("20060102", value)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(icalTimeFormat, value)
+	}
+	loc := time.UTC
+	if tzid := prop.Params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
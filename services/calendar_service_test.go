@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarFeed(t *testing.T) {
+	list := newTestList(t, testUser)
+	due := time.Date(2026, 12, 1, 8, 0, 0, 0, time.UTC)
+	item, err := AddItemToList(context.Background(), list.ID, testUser, &models.TodoItem{Content: "Fatura, kira; aidat", DueDate: &due})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := CreateCalendarToken(context.Background(), testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feed, err := GetCalendarFeed(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ics := string(feed)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:item-" + strconv.Itoa(item.ID) + "@priviatodolist\r\n",
+		`SUMMARY:Fatura\, kira\; aidat` + "\r\n",
+		"DUE:20261201T080000Z\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("feed does not contain %q", want)
+		}
+	}
+
+	// Yeni token eskisini geçersiz kılar; iptal edilen token ile takvim bulunamaz
+	newToken, _ := CreateCalendarToken(context.Background(), testUser)
+	if _, err := GetCalendarFeed(context.Background(), token); err == nil {
+		t.Error("old token still works")
+	}
+	if err := RevokeCalendarToken(context.Background(), testUser); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetCalendarFeed(context.Background(), newToken); err == nil || err.Error() != "calendar not found" {
+		t.Errorf("revoked token: err = %v", err)
+	}
+
+	impersonated := testUser
+	impersonated.ImpersonatorID = testAdmin.UserID
+	if _, err := CreateCalendarToken(context.Background(), impersonated); err == nil {
+		t.Error("calendar token created in an impersonation session")
+	}
+}
+
+func TestImportCalendarItems(t *testing.T) {
+	list := newTestList(t, testUser)
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Tarihli\r\nDUE;VALUE=DATE:20261105\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:İstanbul saati\r\nDUE;TZID=Europe/Istanbul:20261105T120000\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\n" +
+		"END:VCALENDAR\r\n"
+
+	items, problems, err := ImportCalendarItems(context.Background(), list.ID, testUser, []byte(ics))
+	if err != nil {
+		t.Fatalf("%v %+v", err, problems)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items", len(items))
+	}
+	if !items[0].DueDate.Equal(time.Date(2026, 11, 5, 0, 0, 0, 0, time.UTC)) || items[0].IsDone {
+		t.Errorf("first = %+v", items[0])
+	}
+	if !items[1].DueDate.Equal(time.Date(2026, 11, 5, 9, 0, 0, 0, time.UTC)) || !items[1].IsDone {
+		t.Errorf("second = %+v (due %v)", items[1], items[1].DueDate)
+	}
+
+	// Geçersiz bir VTODO varsa hiçbiri eklenmez
+	bad := "BEGIN:VTODO\r\nSUMMARY:Geçerli\r\nEND:VTODO\r\nBEGIN:VTODO\r\nSUMMARY:\r\nEND:VTODO\r\nBEGIN:VTODO\r\nSUMMARY:x\r\nDUE:yarın\r\nEND:VTODO\r\n"
+	_, problems, err = ImportCalendarItems(context.Background(), list.ID, testUser, []byte(bad))
+	if err == nil || len(problems) != 2 || problems[0].Line != 2 || problems[1].Line != 3 {
+		t.Fatalf("err = %v, problems = %+v", err, problems)
+	}
+	if current, _ := GetItems(context.Background(), list.ID, testUser); len(current) != 2 {
+		t.Fatalf("list has %d items after a rejected import", len(current))
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+)
+
+// Liste ve item değişiklikleri yalnızca bu fonksiyonla event olarak yayınlanır;
+// güncel durum repositories katmanında event'lerden oluşturulur.
+func emit(ctx context.Context, actor models.Actor, event *models.DomainEvent) (*models.DomainEvent, error) {
+	span := startRepositorySpan(ctx, "AppendEvent")
+	span.SetAttr("event.type", event.Type)
+	defer span.End()
+
+	event.ActorID = actor.UserID
+	appended, err := repositories.AppendEvent(event)
+	span.SetError(err)
+	return appended, err
+}
+
+// Event'leri tek seferde yayınlar; biri bile uygulanamazsa hiçbiri uygulanmaz
+func emitAll(ctx context.Context, actor models.Actor, events []*models.DomainEvent) ([]*models.DomainEvent, error) {
+	span := startRepositorySpan(ctx, "AppendEvents")
+	span.SetAttr("event.count", len(events))
+	defer span.End()
+
+	for _, event := range events {
+		event.ActorID = actor.UserID
+	}
+	appended, err := repositories.AppendEvents(events)
+	span.SetError(err)
+	return appended, err
+}
+
+// Admin için: verilen sıra numarasından sonraki event'leri getir
+func GetEvents(ctx context.Context, actor models.Actor, since int64) ([]*models.DomainEvent, error) {
+	ctx, span := startSpan(ctx, "GetEvents")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.EventsManage) {
+		return nil, errors.New("forbidden")
+	}
+	return getEventsSince(ctx, since)
+}
+
+// Admin için: liste ve item durumunu event log'dan baştan oluştur
+func ReplayEvents(ctx context.Context, actor models.Actor) (int64, error) {
+	ctx, span := startSpan(ctx, "ReplayEvents")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.EventsManage) {
+		return 0, errors.New("forbidden")
+	}
+	if err := repositories.RebuildProjection(); err != nil {
+		return 0, err
+	}
+	return repositories.GetLastEventSeq(), nil
+}
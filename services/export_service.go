@@ -0,0 +1,250 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tek seferde içe aktarılabilecek en fazla item sayısı
+const MaxImportItems = 1000
+
+// Listeyi ve aktif item'larını istenen formatta dışa aktarır
+func ExportTodoList(ctx context.Context, listID int, actor models.Actor, format string) ([]byte, *models.TodoList, error) {
+	ctx, span := startSpan(ctx, "ExportTodoList")
+	defer span.End()
+
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil || list.DeletedAt != nil {
+		return nil, nil, errors.New("list not found")
+	}
+	if !policy.CanAccess(actor.Role, actor.UserID, list.UserID, policy.ResourceLists, policy.ActionRead) {
+		return nil, nil, errors.New("forbidden")
+	}
+	items, err := getItemsByListID(ctx, listID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Item'lar eklenme sırasıyla yazılır
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	export := models.TodoListExport{Name: list.Name, Items: []models.TodoItemCreate{}}
+	for _, item := range items {
+		export.Items = append(export.Items, models.TodoItemCreate{Content: item.Content, IsDone: item.IsDone, DueDate: item.DueDate})
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case models.ExportJSON:
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(export)
+	case models.ExportCSV:
+		w := csv.NewWriter(&buf)
+		w.Write([]string{"content", "is_done", "due_date"})
+		for _, item := range export.Items {
+			dueDate := ""
+			if item.DueDate != nil {
+				dueDate = item.DueDate.Format(time.RFC3339)
+			}
+			w.Write([]string{item.Content, strconv.FormatBool(item.IsDone), dueDate})
+		}
+		w.Flush()
+		err = w.Error()
+	case models.ExportMarkdown:
+		fmt.Fprintf(&buf, "# %s\n\n", export.Name)
+		for _, item := range export.Items {
+			mark := " "
+			if item.IsDone {
+				mark = "x"
+			}
+			fmt.Fprintf(&buf, "- [%s] %s\n", mark, strings.ReplaceAll(item.Content, "\n", " "))
+		}
+	default:
+		return nil, nil, errors.New("unsupported format")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return buf.Bytes(), list, nil
+}
+
+// Dosyadan yeni bir liste oluşturur. name boş değilse dosyadaki liste adının yerine geçer (CSV'de zorunludur).
+// Liste ve item'lar, listeye elle eklemeyle aynı kurallarla önce doğrulanır; bir sorun varsa hiçbir şey oluşturulmaz.
+func ImportTodoList(ctx context.Context, actor models.Actor, format, name string, data []byte) (*models.TodoList, []models.ImportProblem, error) {
+	ctx, span := startSpan(ctx, "ImportTodoList")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.ListsWriteOwn) || !policy.HasPermission(actor.Role, policy.ItemsWriteOwn) {
+		return nil, nil, errors.New("unauthorized")
+	}
+
+	var export *models.TodoListExport
+	var problems []models.ImportProblem
+	var err error
+	switch format {
+	case models.ExportJSON:
+		export, problems, err = parseJSONImport(data)
+	case models.ExportCSV:
+		export, problems, err = parseCSVImport(data)
+	case models.ExportMarkdown:
+		export, problems, err = parseMarkdownImport(data)
+	default:
+		return nil, nil, errors.New("unsupported format")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if name != "" {
+		export.Name = name
+	}
+
+	if len(export.Name) < 3 {
+		problems = append(problems, models.ImportProblem{Error: "title must be at least 3 characters"})
+	}
+	if len(export.Items) > MaxImportItems {
+		problems = append(problems, models.ImportProblem{Error: "too many items"})
+	}
+	if len(problems) > 0 {
+		return nil, problems, errors.New("invalid import")
+	}
+
+	// Liste ve item'lar tek seferde eklenir; yarım kalmış bir içe aktarma log'a girmez
+	events := []*models.DomainEvent{{Type: models.EventListCreated, OwnerID: actor.UserID, Name: export.Name}}
+	for _, item := range export.Items {
+		events = append(events, &models.DomainEvent{
+			Type:    models.EventItemAdded,
+			OwnerID: actor.UserID,
+			Content: item.Content,
+			IsDone:  item.IsDone,
+			DueDate: item.DueDate,
+		})
+	}
+	appended, err := emitAll(ctx, actor, events)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, err := getTodoListByID(ctx, appended[0].ListID)
+	if err != nil {
+		return nil, nil, err
+	}
+	RecordAudit(ctx, actor, "list.create", AuditTargetList, list.ID, nil, listSnapshot(list))
+	for _, event := range appended[1:] {
+		if item, err := getItemByID(ctx, event.ItemID); err == nil {
+			RecordAudit(ctx, actor, "item.create", AuditTargetItem, item.ID, nil, itemSnapshot(item))
+		}
+	}
+
+	result := *list
+	result.Items, _ = getItemsByListID(ctx, list.ID, false)
+	CalculateListCompletion(&result)
+	return &result, nil, nil
+}
+
+// Item'ı doğrular; geçersizse satır numarasıyla birlikte sorun listesine ekler
+func checkImportItem(problems []models.ImportProblem, line int, item models.TodoItemCreate) []models.ImportProblem {
+	if err := ValidateItem(&models.TodoItem{Content: item.Content, IsDone: item.IsDone, DueDate: item.DueDate}); err != nil {
+		problems = append(problems, models.ImportProblem{Line: line, Error: err.Error()})
+	}
+	return problems
+}
+
+// JSON'da satır numarası yerine item'ın 1'den başlayan sırası döner
+func parseJSONImport(data []byte) (*models.TodoListExport, []models.ImportProblem, error) {
+	var export models.TodoListExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, nil, errors.New("invalid json")
+	}
+	var problems []models.ImportProblem
+	for i, item := range export.Items {
+		problems = checkImportItem(problems, i+1, item)
+	}
+	return &export, problems, nil
+}
+
+// CSV sütunları: content, is_done ve due_date (RFC 3339, isteğe bağlı). İlk satır "content" ile başlıyorsa başlık sayılır.
+func parseCSVImport(data []byte) (*models.TodoListExport, []models.ImportProblem, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	export := &models.TodoListExport{}
+	var problems []models.ImportProblem
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, errors.New("invalid csv")
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "content") {
+			continue
+		}
+
+		item := models.TodoItemCreate{Content: record[0]}
+		if len(record) > 1 && strings.TrimSpace(record[1]) != "" {
+			done, err := strconv.ParseBool(strings.TrimSpace(record[1]))
+			if err != nil {
+				problems = append(problems, models.ImportProblem{Line: line, Error: "is_done must be true or false"})
+				continue
+			}
+			item.IsDone = done
+		}
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			dueDate, err := time.Parse(time.RFC3339, strings.TrimSpace(record[2]))
+			if err != nil {
+				problems = append(problems, models.ImportProblem{Line: line, Error: "due_date must be an RFC 3339 time"})
+				continue
+			}
+			item.DueDate = &dueDate
+		}
+		problems = checkImportItem(problems, line, item)
+		export.Items = append(export.Items, item)
+	}
+	return export, problems, nil
+}
+
+// Markdown: "# Liste adı" başlığı ve "- [ ] içerik" / "- [x] içerik" satırları; diğer satırlar yok sayılır
+func parseMarkdownImport(data []byte) (*models.TodoListExport, []models.ImportProblem, error) {
+	export := &models.TodoListExport{}
+	var problems []models.ImportProblem
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if export.Name == "" && strings.HasPrefix(text, "# ") {
+			export.Name = strings.TrimSpace(strings.TrimPrefix(text, "# "))
+			continue
+		}
+		if len(text) < 5 || (text[0] != '-' && text[0] != '*') || text[1] != ' ' || text[2] != '[' || text[4] != ']' {
+			continue
+		}
+
+		item := models.TodoItemCreate{Content: strings.TrimSpace(text[5:])}
+		switch text[3] {
+		case ' ':
+		case 'x', 'X':
+			item.IsDone = true
+		default:
+			continue
+		}
+		problems = checkImportItem(problems, line, item)
+		export.Items = append(export.Items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, errors.New("invalid markdown")
+	}
+	return export, problems, nil
+}
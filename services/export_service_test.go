@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportedItems(t *testing.T, listID int) []models.TodoItemCreate {
+	t.Helper()
+	items, err := repositories.GetItemsByListID(listID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	var out []models.TodoItemCreate
+	for _, item := range items {
+		out = append(out, models.TodoItemCreate{Content: item.Content, IsDone: item.IsDone, DueDate: item.DueDate})
+	}
+	return out
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	due := time.Date(2026, 11, 2, 9, 30, 0, 0, time.UTC)
+	list := newTestList(t, testUser)
+	newTestItem(t, testUser, list.ID, "Süt, yumurta")
+	done := newTestItem(t, testUser, list.ID, `"Tırnaklı" iş`)
+	if _, err := UpdateItem(context.Background(), done.ID, testUser, &models.TodoItem{Content: done.Content, IsDone: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddItemToList(context.Background(), list.ID, testUser, &models.TodoItem{Content: "Randevu", DueDate: &due}); err != nil {
+		t.Fatal(err)
+	}
+	deleted := newTestItem(t, testUser, list.ID, "silinen")
+	if err := DeleteItem(context.Background(), deleted.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	want := exportedItems(t, list.ID)
+
+	for _, format := range []string{models.ExportJSON, models.ExportCSV, models.ExportMarkdown} {
+		t.Run(format, func(t *testing.T) {
+			data, _, err := ExportTodoList(context.Background(), list.ID, testUser, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(data), "silinen") {
+				t.Fatal("deleted item was exported")
+			}
+			name := ""
+			if format == models.ExportCSV {
+				name = "CSV kopyası"
+			}
+			imported, problems, err := ImportTodoList(context.Background(), testUser, format, name, data)
+			if err != nil {
+				t.Fatalf("import: %v %+v", err, problems)
+			}
+			if format != models.ExportCSV && imported.Name != list.Name {
+				t.Fatalf("name = %q", imported.Name)
+			}
+
+			got := exportedItems(t, imported.ID)
+			if len(got) != len(want) {
+				t.Fatalf("got %d items, want %d", len(got), len(want))
+			}
+			for i := range want {
+				if got[i].Content != want[i].Content || got[i].IsDone != want[i].IsDone {
+					t.Errorf("item %d = %+v, want %+v", i, got[i], want[i])
+				}
+				// Markdown bitiş tarihini taşımaz
+				if format != models.ExportMarkdown && (got[i].DueDate == nil) != (want[i].DueDate == nil) {
+					t.Errorf("item %d due date = %v, want %v", i, got[i].DueDate, want[i].DueDate)
+				}
+				if format != models.ExportMarkdown && got[i].DueDate != nil && !got[i].DueDate.Equal(*want[i].DueDate) {
+					t.Errorf("item %d due date = %v, want %v", i, got[i].DueDate, want[i].DueDate)
+				}
+			}
+		})
+	}
+}
+
+func TestImportRejectsInvalidFilesAtomically(t *testing.T) {
+	before, _ := repositories.GetTodoListsByUserID(testUser.UserID, true)
+
+	csv := "content,is_done,due_date\nİyi satır,false,\n,false,\nTarih,false,yarın\n"
+	_, problems, err := ImportTodoList(context.Background(), testUser, models.ExportCSV, "Bozuk CSV", []byte(csv))
+	if err == nil || err.Error() != "invalid import" {
+		t.Fatalf("err = %v", err)
+	}
+	if len(problems) != 2 || problems[0].Line != 3 || problems[1].Line != 4 {
+		t.Fatalf("problems = %+v", problems)
+	}
+
+	if _, _, err := ImportTodoList(context.Background(), testUser, models.ExportCSV, "", []byte("content,is_done,due_date\nx,false,\n")); err == nil {
+		t.Fatal("CSV without a name was imported")
+	}
+	if _, _, err := ImportTodoList(context.Background(), testUser, models.ExportJSON, "", []byte("{")); err == nil {
+		t.Fatal("broken JSON was imported")
+	}
+	if _, _, err := ImportTodoList(context.Background(), testUser, "xml", "", nil); err == nil || err.Error() != "unsupported format" {
+		t.Fatalf("xml: err = %v", err)
+	}
+
+	after, _ := repositories.GetTodoListsByUserID(testUser.UserID, true)
+	if len(after) != len(before) {
+		t.Fatal("a rejected import created a list")
+	}
+}
+
+// Liste ve item'ları tek seferde eklenir; eşzamanlı yazmalar içe aktarılan event'lerin arasına girmez
+func TestImportAppendsEventsTogether(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			if _, err := CreateTodoList(context.Background(), testAdmin, &models.TodoList{Name: "Eşzamanlı"}); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+
+	since := repositories.GetLastEventSeq()
+	data := `{"name":"Toplu içe aktarma","items":[{"content":"Bir"},{"content":"İki","is_done":true},{"content":"Üç"}]}`
+	list, _, err := ImportTodoList(context.Background(), testUser, models.ExportJSON, "", []byte(data))
+	<-done
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events, _ := repositories.GetEventsSince(since)
+	var seqs []int64
+	for _, event := range events {
+		if event.ListID == list.ID {
+			seqs = append(seqs, event.Seq)
+		}
+	}
+	if len(seqs) != 4 || seqs[3]-seqs[0] != 3 {
+		t.Fatalf("import event seqs = %v, want 4 consecutive events", seqs)
+	}
+	if len(list.Items) != 3 || list.Completion == 0 {
+		t.Fatalf("imported list = %+v", list)
+	}
+}
+
+func TestExportRequiresAccess(t *testing.T) {
+	list := newTestList(t, testUser)
+	other := models.Actor{UserID: 3, Username: "user2", Role: "user"}
+	if _, _, err := ExportTodoList(context.Background(), list.ID, other, models.ExportJSON); err == nil || err.Error() != "forbidden" {
+		t.Fatalf("err = %v", err)
+	}
+	if err := DeleteTodoList(context.Background(), list.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ExportTodoList(context.Background(), list.ID, testUser, models.ExportJSON); err == nil || err.Error() != "list not found" {
+		t.Fatalf("deleted list: err = %v", err)
+	}
+}
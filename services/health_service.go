@@ -0,0 +1,63 @@
+package services
+
+import (
+	"priviatodolist/config"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"sync"
+)
+
+// Hazır olmak için çalışıyor olması gereken arka plan işleri
+const (
+	WorkerTrashRetention  = "trash_retention"
+	WorkerWebhookDispatch = "webhook_dispatcher"
+	WorkerStreamBroker    = "stream_broker"
+)
+
+var expectedWorkers = []string{WorkerTrashRetention, WorkerWebhookDispatch, WorkerStreamBroker}
+
+var (
+	workersMu sync.Mutex
+	workers   = map[string]bool{}
+)
+
+func setWorkerRunning(name string, running bool) {
+	workersMu.Lock()
+	defer workersMu.Unlock()
+
+	workers[name] = running
+}
+
+// Servis trafik almaya hazır mı? Depolama, yapılandırma ve arka plan işleri ayrı ayrı kontrol edilir.
+func CheckReadiness(cfg *config.Config) *models.HealthStatus {
+	status := &models.HealthStatus{Status: models.HealthOK, Checks: map[string]string{}}
+	fail := func(name, reason string) {
+		status.Status = models.HealthUnavailable
+		status.Checks[name] = reason
+	}
+
+	if err := repositories.Ping(); err != nil {
+		fail("storage", err.Error())
+	} else {
+		status.Checks["storage"] = models.HealthOK
+	}
+
+	if cfg == nil {
+		fail("config", "not loaded")
+	} else if err := cfg.Validate(); err != nil {
+		fail("config", err.Error())
+	} else {
+		status.Checks["config"] = models.HealthOK
+	}
+
+	workersMu.Lock()
+	defer workersMu.Unlock()
+	for _, name := range expectedWorkers {
+		if workers[name] {
+			status.Checks[name] = models.HealthOK
+		} else {
+			fail(name, "not running")
+		}
+	}
+	return status
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+)
+
+// Item'ın değişiklik geçmişini getir
+func GetItemHistory(ctx context.Context, itemID int, actor models.Actor) ([]*models.TodoItemVersion, error) {
+	ctx, span := startSpan(ctx, "GetItemHistory")
+	defer span.End()
+
+	item, err := getItemByID(ctx, itemID)
+	if err != nil {
+		if item, err = getDeletedItemByID(ctx, itemID); err != nil {
+			return nil, errors.New("item not found")
+		}
+	}
+	if !canAccessList(ctx, actor, item.ListID, policy.ResourceItems, policy.ActionRead) {
+		return nil, errors.New("forbidden")
+	}
+
+	versions, err := getItemVersions(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		// Hiç değişmemiş item'ın tek sürümü mevcut halidir
+		versions = []*models.TodoItemVersion{{
+			Version:   1,
+			ItemID:    item.ID,
+			ListID:    item.ListID,
+			Action:    "initial",
+			Content:   item.Content,
+			IsDone:    item.IsDone,
+			UpdatedAt: item.UpdatedAt,
+			DeletedAt: item.DeletedAt,
+		}}
+	}
+	return versions, nil
+}
+
+// Listenin ve item'larının değişiklik geçmişini getir
+func GetListHistory(ctx context.Context, listID int, actor models.Actor) (*models.TodoListHistory, error) {
+	ctx, span := startSpan(ctx, "GetListHistory")
+	defer span.End()
+
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil {
+		return nil, errors.New("list not found")
+	}
+	if !policy.CanAccess(actor.Role, actor.UserID, list.UserID, policy.ResourceLists, policy.ActionRead) {
+		return nil, errors.New("forbidden")
+	}
+
+	listVersions, err := getListVersions(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	if len(listVersions) == 0 {
+		listVersions = []*models.TodoListVersion{{
+			Version:   1,
+			ListID:    list.ID,
+			Action:    "initial",
+			Name:      list.Name,
+			UpdatedAt: list.UpdatedAt,
+			DeletedAt: list.DeletedAt,
+		}}
+	}
+	itemVersions, err := getItemVersionsByListID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TodoListHistory{List: listVersions, Items: itemVersions}, nil
+}
+
+// Item'ın içeriğini ve tamamlanma durumunu verilen sürüme geri döndür
+func RevertItem(ctx context.Context, itemID, version int, actor models.Actor) (*models.TodoItem, error) {
+	ctx, span := startSpan(ctx, "RevertItem")
+	defer span.End()
+
+	item, err := getItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccessList(ctx, actor, item.ListID, policy.ResourceItems, policy.ActionWrite) {
+		return nil, errors.New("unauthorized")
+	}
+	target, err := getItemVersion(ctx, itemID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := getTodoListByID(ctx, item.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := itemSnapshot(item)
+	if _, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventItemReverted,
+		OwnerID: list.UserID,
+		ListID:  item.ListID,
+		ItemID:  itemID,
+		Content: target.Content,
+		IsDone:  target.IsDone,
+		DueDate: target.DueDate,
+		Version: version,
+	}); err != nil {
+		return nil, err
+	}
+	if item, err = getItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	RecordAudit(ctx, actor, "item.revert", AuditTargetItem, itemID, before, itemSnapshot(item))
+	return item, nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"testing"
+)
+
+func TestItemHistoryAndRevert(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "ilk")
+	if _, err := UpdateItem(context.Background(), item.ID, testUser, &models.TodoItem{Content: "ikinci"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UpdateItem(context.Background(), item.ID, testUser, &models.TodoItem{Content: "üçüncü", IsDone: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := GetItemHistory(context.Background(), item.ID, testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// create + edit + (edit, complete) = 4 sürüm
+	if len(versions) != 4 || versions[0].Action != "create" || versions[0].Content != "ilk" {
+		t.Fatalf("versions = %+v", versions)
+	}
+	last := versions[len(versions)-1]
+	if last.Content != "üçüncü" || !last.IsDone || last.ChangedBy != testUser.UserID {
+		t.Fatalf("last version = %+v", last)
+	}
+
+	reverted, err := RevertItem(context.Background(), item.ID, versions[0].Version, testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reverted.Content != "ilk" || reverted.IsDone {
+		t.Fatalf("reverted = %+v", reverted)
+	}
+	// Geri alma da geçmişe yeni bir sürüm olarak eklenir; eski sürümler korunur
+	versions, _ = GetItemHistory(context.Background(), item.ID, testUser)
+	if len(versions) != 5 || versions[4].Action != "revert" || versions[4].Content != "ilk" {
+		t.Fatalf("versions after revert = %+v", versions)
+	}
+
+	if _, err := RevertItem(context.Background(), item.ID, 99, testUser); err == nil {
+		t.Fatal("reverted to a missing version")
+	}
+}
+
+func TestItemHistoryAccess(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "özel")
+	other := models.Actor{UserID: 3, Username: "user2", Role: "user"}
+
+	if _, err := GetItemHistory(context.Background(), item.ID, other); err == nil || err.Error() != "forbidden" {
+		t.Fatalf("other user's history: err = %v", err)
+	}
+	if _, err := RevertItem(context.Background(), item.ID, 1, other); err == nil || err.Error() != "unauthorized" {
+		t.Fatalf("other user's revert: err = %v", err)
+	}
+	// Admin tüm listeleri okuyabilir
+	if _, err := GetItemHistory(context.Background(), item.ID, testAdmin); err != nil {
+		t.Fatalf("admin history: %v", err)
+	}
+
+	// Silinen item'ın geçmişi okunabilir ama geri alınamaz
+	if err := DeleteItem(context.Background(), item.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := GetItemHistory(context.Background(), item.ID, testUser)
+	if err != nil || versions[len(versions)-1].Action != "delete" {
+		t.Fatalf("deleted item history: %+v, %v", versions, err)
+	}
+	if _, err := RevertItem(context.Background(), item.ID, 1, testUser); err == nil {
+		t.Fatal("reverted a deleted item")
+	}
+
+	history, err := GetListHistory(context.Background(), list.ID, testUser)
+	if err != nil || len(history.Items) != 2 {
+		t.Fatalf("list history: %+v, %v", history, err)
+	}
+}
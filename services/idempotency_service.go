@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"time"
+)
+
+// Kabul edilen en uzun anahtar
+const MaxIdempotencyKeyLength = 255
+
+// Anahtarı kullanıcı için ttl süresince ayırır. Anahtar daha önce aynı istekle kullanıldıysa saklanan kaydı döner;
+// ilk kullanımda nil döner ve istek işlendikten sonra CompleteIdempotentRequest çağrılmalıdır.
+func BeginIdempotentRequest(userID int, key, fingerprint string, ttl time.Duration) (*models.IdempotencyRecord, error) {
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, errors.New("idempotency key too long")
+	}
+
+	now := time.Now()
+	existing, err := repositories.ReserveIdempotencyRecord(&models.IdempotencyRecord{
+		Key:         key,
+		UserID:      userID,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	})
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	if existing.Fingerprint != fingerprint {
+		return nil, errors.New("idempotency key reused")
+	}
+	if !existing.Completed {
+		return nil, errors.New("idempotency key in progress")
+	}
+	return existing, nil
+}
+
+// İsteğin yanıtını saklar; aynı anahtarla gelen tekrarlarda bu yanıt döner
+func CompleteIdempotentRequest(userID int, key string, statusCode int, header http.Header, body []byte) error {
+	return repositories.CompleteIdempotencyRecord(userID, key, statusCode, header, body)
+}
+
+// Yanıt saklanmadan anahtarı bırakır (örneğin sunucu hatasında istemci tekrar deneyebilsin diye)
+func ReleaseIdempotentRequest(userID int, key string) {
+	repositories.DeleteIdempotencyRecord(userID, key)
+}
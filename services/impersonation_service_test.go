@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"testing"
+	"time"
+)
+
+func TestImpersonateStartsShortSessionAndIsAudited(t *testing.T) {
+	auditStart := len(mockdb.AuditLog)
+	user, session, err := Impersonate(context.Background(), 1, testAdmin, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("Impersonate: %v", err)
+	}
+	if user.ID != 1 || session.UserID != 1 || session.ImpersonatorID != 2 {
+		t.Fatalf("user=%d session=%+v", user.ID, session)
+	}
+	if ttl := time.Until(session.ExpiresAt); ttl > 30*time.Minute || ttl < 29*time.Minute {
+		t.Fatalf("session expires in %v, want 30m", ttl)
+	}
+	if err := ValidateSession(session.ID, 1, 2); err != nil {
+		t.Fatalf("ValidateSession: %v", err)
+	}
+	// Oturum impersonator olmadan kullanılamaz
+	if err := ValidateSession(session.ID, 1, 0); err == nil {
+		t.Fatal("impersonation session accepted without impersonator")
+	}
+
+	audited := false
+	for _, event := range mockdb.AuditLog[auditStart:] {
+		if event.Action == "impersonation.start" && event.ActorID == 2 && event.TargetID == 1 {
+			audited = true
+		}
+	}
+	if !audited {
+		t.Fatal("impersonation.start was not audited")
+	}
+}
+
+func TestImpersonateRejections(t *testing.T) {
+	cases := []struct {
+		name   string
+		userID int
+		actor  models.Actor
+		want   string
+	}{
+		{"self", 2, testAdmin, "cannot impersonate yourself"},
+		{"regular user", 3, models.Actor{UserID: 1, Username: "user1", Role: "user"}, "forbidden"},
+		{"nested", 3, models.Actor{UserID: 1, Username: "user1", Role: "admin", ImpersonatorID: 2}, "forbidden"},
+	}
+	for _, tc := range cases {
+		if _, _, err := Impersonate(context.Background(), tc.userID, tc.actor, time.Hour); err == nil || err.Error() != tc.want {
+			t.Errorf("%s: err = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestImpersonationEndsWhenImpersonatorIsDisabled(t *testing.T) {
+	actor := newTestAdmin(t, "impersonating-admin")
+	_, session, err := Impersonate(context.Background(), 1, actor, time.Hour)
+	if err != nil {
+		t.Fatalf("Impersonate: %v", err)
+	}
+
+	if _, err := SetUserDisabled(context.Background(), actor.UserID, testAdmin, true); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	if err := ValidateSession(session.ID, 1, actor.UserID); err == nil || err.Error() != "impersonator not allowed" {
+		t.Fatalf("ValidateSession after disable: %v", err)
+	}
+}
+
+// Admin başka bir admin'in kimliğini ve yetkilerini devralamaz
+func TestImpersonateRejectsPrivilegedTargets(t *testing.T) {
+	admin := newTestAdmin(t, "impersonated-admin")
+	if _, _, err := Impersonate(context.Background(), admin.UserID, testAdmin, time.Hour); err == nil || err.Error() != "forbidden" {
+		t.Fatalf("impersonating an admin: err = %v", err)
+	}
+	sessions, _ := repositories.GetActiveSessionsByUserID(admin.UserID)
+	if len(sessions) != 0 {
+		t.Fatalf("session opened for a rejected impersonation: %+v", sessions)
+	}
+}
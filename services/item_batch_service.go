@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+)
+
+// Tek istekte kabul edilen en fazla işlem sayısı
+const MaxBatchOperations = 100
+
+// Bir listedeki item'lar üzerinde toplu create/update/delete/complete işlemi yapar.
+// atomic modda tüm işlemler önce doğrulanır; biri bile geçersizse hiçbiri uygulanmaz.
+func BatchItems(ctx context.Context, listID int, actor models.Actor, req *models.ItemBatchRequest) (*models.ItemBatchResponse, error) {
+	ctx, span := startSpan(ctx, "BatchItems")
+	defer span.End()
+
+	mode := req.Mode
+	if mode == "" {
+		mode = models.BatchAtomic
+	}
+	if mode != models.BatchAtomic && mode != models.BatchPartial {
+		return nil, errors.New("unknown batch mode")
+	}
+	if len(req.Operations) == 0 {
+		return nil, errors.New("no operations")
+	}
+	if len(req.Operations) > MaxBatchOperations {
+		return nil, errors.New("too many operations")
+	}
+
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil || list.DeletedAt != nil {
+		return nil, errors.New("list not found")
+	}
+	if !canAccessList(ctx, actor, listID, policy.ResourceItems, policy.ActionWrite) {
+		return nil, errors.New("unauthorized")
+	}
+
+	response := &models.ItemBatchResponse{Results: make([]models.ItemBatchResult, len(req.Operations))}
+	if mode == models.BatchAtomic {
+		return response, applyAtomicBatch(ctx, list, actor, req.Operations, response)
+	}
+
+	deleted := map[int]bool{}
+	for i, op := range req.Operations {
+		result := models.ItemBatchResult{Index: i, Op: op.Op, ID: op.ID, Status: "ok"}
+		item, err := applyBatchOperation(ctx, listID, actor, op, deleted)
+		if err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+		} else {
+			result.ID = item.ID
+			result.Item = itemSnapshot(item)
+			response.Applied = true
+		}
+		response.Results[i] = result
+	}
+
+	// Completion oranı tüm işlemlerden sonra bir kez hesaplanır
+	response.List = batchListSnapshot(ctx, list)
+	return response, nil
+}
+
+// atomic mod: işlemler doğrulanır, ardından tüm işlemlerin event'leri tek seferde eklenir.
+// Doğrulamadan sonra başka bir istek item'ları değiştirdiyse ekleme de reddedilir; hiçbir işlem uygulanmaz.
+func applyAtomicBatch(ctx context.Context, list *models.TodoList, actor models.Actor, ops []models.ItemBatchOperation, response *models.ItemBatchResponse) error {
+	if !validateBatch(ctx, list.ID, ops, response) {
+		response.List = batchListSnapshot(ctx, list)
+		return errors.New("batch validation failed")
+	}
+
+	// Aynı item üzerindeki ardışık işlemlerin farkı item'ın çalışma kopyası üzerinden hesaplanır
+	working := map[int]*models.TodoItem{}
+	befores := make([]*models.TodoItem, len(ops))
+	changed := make([]bool, len(ops))
+	created := map[int]int{} // işlem sırası -> ItemAdded event'inin sırası
+	var events []*models.DomainEvent
+	for i, op := range ops {
+		if op.Op == models.BatchOpCreate {
+			created[i] = len(events)
+			events = append(events, &models.DomainEvent{
+				Type:    models.EventItemAdded,
+				OwnerID: list.UserID,
+				ListID:  list.ID,
+				Content: op.Content,
+				IsDone:  op.IsDone,
+				DueDate: op.DueDate,
+			})
+			changed[i] = true
+			continue
+		}
+
+		item, exists := working[op.ID]
+		if !exists {
+			current, err := getItemByID(ctx, op.ID)
+			if err != nil {
+				return atomicBatchFailed(ctx, list, ops, response, err)
+			}
+			item = current
+			working[op.ID] = item
+		}
+		befores[i] = itemSnapshot(item)
+
+		var opEvents []*models.DomainEvent
+		switch op.Op {
+		case models.BatchOpUpdate:
+			opEvents = itemUpdateEvents(item, &models.TodoItem{Content: op.Content, IsDone: op.IsDone, DueDate: op.DueDate})
+			item.Content, item.IsDone, item.DueDate = op.Content, op.IsDone, op.DueDate
+		case models.BatchOpComplete:
+			opEvents = itemUpdateEvents(item, &models.TodoItem{Content: item.Content, IsDone: true, DueDate: item.DueDate})
+			item.IsDone = true
+		default:
+			opEvents = []*models.DomainEvent{{Type: models.EventItemDeleted}}
+		}
+		for _, event := range opEvents {
+			event.OwnerID = list.UserID
+			event.ListID = list.ID
+			event.ItemID = op.ID
+		}
+		events = append(events, opEvents...)
+		changed[i] = len(opEvents) > 0
+	}
+
+	appended, err := emitAll(ctx, actor, events)
+	if err != nil {
+		return atomicBatchFailed(ctx, list, ops, response, err)
+	}
+
+	for i, op := range ops {
+		id := op.ID
+		if op.Op == models.BatchOpCreate {
+			id = appended[created[i]].ItemID
+		}
+		item, err := currentItem(ctx, id)
+		if err != nil {
+			return err
+		}
+		response.Results[i] = models.ItemBatchResult{Index: i, Op: op.Op, ID: id, Status: "ok", Item: itemSnapshot(item)}
+
+		switch {
+		case op.Op == models.BatchOpCreate:
+			RecordAudit(ctx, actor, "item.create", AuditTargetItem, id, nil, itemSnapshot(item))
+		case op.Op == models.BatchOpDelete:
+			RecordAudit(ctx, actor, "item.delete", AuditTargetItem, id, befores[i], itemSnapshot(item))
+		case changed[i]:
+			RecordAudit(ctx, actor, "item.update", AuditTargetItem, id, befores[i], itemSnapshot(item))
+		}
+	}
+	response.Applied = true
+	response.List = batchListSnapshot(ctx, list)
+	return nil
+}
+
+// Ekleme reddedildiğinde işlemler güncel duruma göre yeniden doğrulanır; hangi işlemin geçersiz
+// kaldığı bulunamazsa tüm işlemler eklemenin hatasıyla başarısız sayılır
+func atomicBatchFailed(ctx context.Context, list *models.TodoList, ops []models.ItemBatchOperation, response *models.ItemBatchResponse, err error) error {
+	if validateBatch(ctx, list.ID, ops, response) {
+		for i := range response.Results {
+			response.Results[i].Status = "failed"
+			response.Results[i].Error = err.Error()
+		}
+	}
+	response.List = batchListSnapshot(ctx, list)
+	return errors.New("batch validation failed")
+}
+
+// Tüm işlemleri sırayla doğrular ve sonuçları "skipped" veya "failed" olarak doldurur
+func validateBatch(ctx context.Context, listID int, ops []models.ItemBatchOperation, response *models.ItemBatchResponse) bool {
+	valid := true
+	deleted := map[int]bool{}
+	for i, op := range ops {
+		response.Results[i] = models.ItemBatchResult{Index: i, Op: op.Op, ID: op.ID, Status: "skipped"}
+		if err := validateBatchOperation(ctx, listID, op, deleted); err != nil {
+			response.Results[i].Status = "failed"
+			response.Results[i].Error = err.Error()
+			valid = false
+		}
+	}
+	return valid
+}
+
+// İşlemin uygulanabilir olup olmadığını kontrol eder; silinen item'lar deleted kümesine eklenir
+func validateBatchOperation(ctx context.Context, listID int, op models.ItemBatchOperation, deleted map[int]bool) error {
+	switch op.Op {
+	case models.BatchOpCreate:
+		return ValidateItem(&models.TodoItem{Content: op.Content, IsDone: op.IsDone, DueDate: op.DueDate})
+	case models.BatchOpUpdate:
+		if err := ValidateItem(&models.TodoItem{Content: op.Content, IsDone: op.IsDone, DueDate: op.DueDate}); err != nil {
+			return err
+		}
+	case models.BatchOpDelete, models.BatchOpComplete:
+	default:
+		return errors.New("unknown operation")
+	}
+
+	if op.ID == 0 {
+		return errors.New("id is required")
+	}
+	item, err := getItemByID(ctx, op.ID)
+	if err != nil || item.ListID != listID || deleted[op.ID] {
+		return errors.New("item not found")
+	}
+	if op.Op == models.BatchOpDelete {
+		deleted[op.ID] = true
+	}
+	return nil
+}
+
+func applyBatchOperation(ctx context.Context, listID int, actor models.Actor, op models.ItemBatchOperation, deleted map[int]bool) (*models.TodoItem, error) {
+	if err := validateBatchOperation(ctx, listID, op, deleted); err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case models.BatchOpCreate:
+		return AddItemToList(ctx, listID, actor, &models.TodoItem{Content: op.Content, IsDone: op.IsDone, DueDate: op.DueDate})
+	case models.BatchOpUpdate:
+		return UpdateItem(ctx, op.ID, actor, &models.TodoItem{Content: op.Content, IsDone: op.IsDone, DueDate: op.DueDate})
+	case models.BatchOpComplete:
+		item, err := getItemByID(ctx, op.ID)
+		if err != nil {
+			return nil, err
+		}
+		return UpdateItem(ctx, op.ID, actor, &models.TodoItem{Content: item.Content, IsDone: true, DueDate: item.DueDate})
+	default:
+		if err := DeleteItem(ctx, op.ID, actor); err != nil {
+			return nil, err
+		}
+		return getDeletedItemByID(ctx, op.ID)
+	}
+}
+
+// Listenin aktif item'larıyla birlikte completion oranı hesaplanmış kopyası
+func batchListSnapshot(ctx context.Context, list *models.TodoList) *models.TodoList {
+	snapshot := *list
+	snapshot.Items, _ = getItemsByListID(ctx, list.ID, false)
+	CalculateListCompletion(&snapshot)
+	return &snapshot
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"testing"
+)
+
+func TestAtomicBatchRejectsInvalidUpdate(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Dokunulmamalı")
+	before := repositories.GetLastEventSeq()
+
+	response, err := BatchItems(context.Background(), list.ID, testUser, &models.ItemBatchRequest{Operations: []models.ItemBatchOperation{
+		{Op: models.BatchOpCreate, Content: "Yeni"},
+		{Op: models.BatchOpUpdate, ID: item.ID, Content: "   "},
+	}})
+	if err == nil || err.Error() != "batch validation failed" {
+		t.Fatalf("err = %v", err)
+	}
+	if response.Results[0].Status != "skipped" || response.Results[1].Status != "failed" || response.Results[1].Error != "content is required" {
+		t.Fatalf("results = %+v", response.Results)
+	}
+	if response.Applied || repositories.GetLastEventSeq() != before {
+		t.Fatal("atomic batch applied operations despite a validation failure")
+	}
+}
+
+func TestAtomicBatchAppliesAllOperations(t *testing.T) {
+	list := newTestList(t, testUser)
+	edit := newTestItem(t, testUser, list.ID, "Düzenlenecek")
+	done := newTestItem(t, testUser, list.ID, "Tamamlanacak")
+	gone := newTestItem(t, testUser, list.ID, "Silinecek")
+
+	response, err := BatchItems(context.Background(), list.ID, testUser, &models.ItemBatchRequest{Operations: []models.ItemBatchOperation{
+		{Op: models.BatchOpCreate, Content: "Eklendi"},
+		{Op: models.BatchOpUpdate, ID: edit.ID, Content: "Düzenlendi"},
+		{Op: models.BatchOpComplete, ID: done.ID},
+		{Op: models.BatchOpDelete, ID: gone.ID},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range response.Results {
+		if r.Status != "ok" {
+			t.Fatalf("result %d = %+v", r.Index, r)
+		}
+	}
+	if response.Results[0].ID == 0 || response.Results[0].Item.Content != "Eklendi" {
+		t.Fatalf("create result = %+v", response.Results[0])
+	}
+	if response.Results[3].Item.DeletedAt == nil {
+		t.Fatal("delete result does not show the deleted item")
+	}
+	// Eklenen, düzenlenen ve tamamlanan item'lar kalır; 3 item'ın 1'i tamamlanmış
+	if len(response.List.Items) != 3 || int(response.List.Completion) != 33 {
+		t.Fatalf("list has %d items, completion %.1f", len(response.List.Items), response.List.Completion)
+	}
+}
+
+func TestAtomicBatchRejectsOperationsAfterDelete(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Bir kez")
+
+	_, err := BatchItems(context.Background(), list.ID, testUser, &models.ItemBatchRequest{Operations: []models.ItemBatchOperation{
+		{Op: models.BatchOpDelete, ID: item.ID},
+		{Op: models.BatchOpComplete, ID: item.ID},
+	}})
+	if err == nil || err.Error() != "batch validation failed" {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := repositories.GetItemByID(item.ID); err != nil {
+		t.Fatal("item was deleted by a rejected batch")
+	}
+}
+
+func TestPartialBatchReportsPerOperation(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Var")
+
+	response, err := BatchItems(context.Background(), list.ID, testUser, &models.ItemBatchRequest{Mode: models.BatchPartial, Operations: []models.ItemBatchOperation{
+		{Op: models.BatchOpComplete, ID: item.ID},
+		{Op: models.BatchOpUpdate, ID: item.ID, Content: ""},
+		{Op: models.BatchOpDelete, ID: 999999},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := []string{response.Results[0].Status, response.Results[1].Status, response.Results[2].Status}
+	if statuses[0] != "ok" || statuses[1] != "failed" || statuses[2] != "failed" || !response.Applied {
+		t.Fatalf("statuses = %v", statuses)
+	}
+}
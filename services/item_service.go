@@ -1,64 +1,207 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"priviatodolist/models"
-	"priviatodolist/repositories"
+	"priviatodolist/policy"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
-func AddItemToList(listID int, userID int, item *models.TodoItem) (*models.TodoItem, error) {
-	if !ownsList(userID, listID) {
+// Bir item içeriğinin alabileceği en fazla karakter sayısı
+const MaxItemContentLength = 1000
+
+// Item kuralları; ekleme, güncelleme, toplu işlemler, senkronizasyon ve içe aktarma aynı kuralları kullanır
+func ValidateItem(item *models.TodoItem) error {
+	if strings.TrimSpace(item.Content) == "" {
+		return errors.New("content is required")
+	}
+	if utf8.RuneCountInString(item.Content) > MaxItemContentLength {
+		return errors.New("content is too long")
+	}
+	return nil
+}
+
+func AddItemToList(ctx context.Context, listID int, actor models.Actor, item *models.TodoItem) (*models.TodoItem, error) {
+	ctx, span := startSpan(ctx, "AddItemToList")
+	defer span.End()
+
+	if err := ValidateItem(item); err != nil {
+		return nil, err
+	}
+	if !canAccessList(ctx, actor, listID, policy.ResourceItems, policy.ActionWrite) {
 		return nil, errors.New("unauthorized: list does not belong to user")
 	}
-	item.ListID = listID
-	return repositories.CreateItem(item)
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil || list.DeletedAt != nil {
+		return nil, errors.New("list not found")
+	}
+
+	event, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventItemAdded,
+		OwnerID: list.UserID,
+		ListID:  listID,
+		Content: item.Content,
+		IsDone:  item.IsDone,
+		DueDate: item.DueDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	created, err := getItemByID(ctx, event.ItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	RecordAudit(ctx, actor, "item.create", AuditTargetItem, created.ID, nil, itemSnapshot(created))
+	return created, nil
 }
 
-func UpdateItem(itemID int, userID int, updatedItem *models.TodoItem) (*models.TodoItem, error) {
-	item, err := repositories.GetItemByID(itemID)
+func UpdateItem(ctx context.Context, itemID int, actor models.Actor, updatedItem *models.TodoItem) (*models.TodoItem, error) {
+	ctx, span := startSpan(ctx, "UpdateItem")
+	defer span.End()
+
+	if err := ValidateItem(updatedItem); err != nil {
+		return nil, err
+	}
+	item, err := getItemByID(ctx, itemID)
 	if err != nil {
 		return nil, err
 	}
-	if !ownsList(userID, item.ListID) {
+	if !canAccessList(ctx, actor, item.ListID, policy.ResourceItems, policy.ActionWrite) {
 		return nil, errors.New("unauthorized")
 	}
-	return repositories.UpdateItem(itemID, updatedItem)
+
+	list, err := getTodoListByID(ctx, item.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := itemSnapshot(item)
+	events := itemUpdateEvents(item, updatedItem)
+	for _, event := range events {
+		event.OwnerID = list.UserID
+		event.ListID = item.ListID
+		event.ItemID = itemID
+	}
+
+	// Alan değişiklikleri tek seferde eklenir; biri uygulanamazsa hiçbiri eklenmez
+	if len(events) > 0 {
+		if _, err := emitAll(ctx, actor, events); err != nil {
+			return nil, err
+		}
+		if item, err = getItemByID(ctx, itemID); err != nil {
+			return nil, err
+		}
+		RecordAudit(ctx, actor, "item.update", AuditTargetItem, itemID, before, itemSnapshot(item))
+	}
+	return item, nil
 }
 
-func DeleteItem(itemID int, userID int) error {
-	item, err := repositories.GetItemByID(itemID)
+// İçerik, tamamlanma ve bitiş tarihi değişiklikleri ayrı event'ler olarak yayınlanır.
+// Dönen event'lerin liste, sahip ve item bilgileri çağıran tarafından doldurulur.
+func itemUpdateEvents(item, updatedItem *models.TodoItem) []*models.DomainEvent {
+	var events []*models.DomainEvent
+	if updatedItem.Content != item.Content {
+		events = append(events, &models.DomainEvent{Type: models.EventItemEdited, Content: updatedItem.Content})
+	}
+	if updatedItem.IsDone != item.IsDone {
+		eventType := models.EventItemReopened
+		if updatedItem.IsDone {
+			eventType = models.EventItemCompleted
+		}
+		events = append(events, &models.DomainEvent{Type: eventType})
+	}
+	if !sameTime(updatedItem.DueDate, item.DueDate) {
+		events = append(events, &models.DomainEvent{Type: models.EventItemRescheduled, DueDate: updatedItem.DueDate})
+	}
+	return events
+}
+
+func DeleteItem(ctx context.Context, itemID int, actor models.Actor) error {
+	ctx, span := startSpan(ctx, "DeleteItem")
+	defer span.End()
+
+	item, err := getItemByID(ctx, itemID)
 	if err != nil {
 		return err
 	}
-	if !ownsList(userID, item.ListID) {
+	if !canAccessList(ctx, actor, item.ListID, policy.ResourceItems, policy.ActionWrite) {
 		return errors.New("unauthorized")
 	}
-	return repositories.DeleteItem(itemID)
+
+	list, err := getTodoListByID(ctx, item.ListID)
+	if err != nil {
+		return err
+	}
+
+	before := itemSnapshot(item)
+	if _, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventItemDeleted,
+		OwnerID: list.UserID,
+		ListID:  item.ListID,
+		ItemID:  itemID,
+	}); err != nil {
+		return err
+	}
+	if item, err = getDeletedItemByID(ctx, itemID); err != nil {
+		return err
+	}
+
+	RecordAudit(ctx, actor, "item.delete", AuditTargetItem, itemID, before, itemSnapshot(item))
+	return nil
 }
 
-func GetItems(listID int, userID int) ([]*models.TodoItem, error) {
+func GetItems(ctx context.Context, listID int, actor models.Actor) ([]*models.TodoItem, error) {
+	ctx, span := startSpan(ctx, "GetItems")
+	defer span.End()
 
-	list, err := repositories.GetTodoListByID(listID)
+	list, err := getTodoListByID(ctx, listID)
 	if err != nil {
 		return nil, errors.New("list not found")
 	}
 
-	if list.UserID != userID {
+	if !policy.CanAccess(actor.Role, actor.UserID, list.UserID, policy.ResourceItems, policy.ActionRead) {
 		return nil, errors.New("forbidden")
 	}
 
-	return repositories.GetItemsByListID(listID, false)
+	return getItemsByListID(ctx, listID, false)
 }
 
-func GetAllItemsForAdmin(listID int) ([]*models.TodoItem, error) {
-	_, err := repositories.GetItemsByListID(listID, true)
+func GetAllItemsForAdmin(ctx context.Context, listID int, actor models.Actor) ([]*models.TodoItem, error) {
+	ctx, span := startSpan(ctx, "GetAllItemsForAdmin")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.ItemsReadAny) {
+		return nil, errors.New("forbidden")
+	}
+	_, err := getItemsByListID(ctx, listID, true)
 	if err != nil {
 		return nil, errors.New("list not found")
 	}
-	items, err := repositories.GetItemsByListID(listID, true)
+	items, err := getItemsByListID(ctx, listID, true)
 	if err != nil {
 		return nil, err
 	}
 
 	return items, nil
 }
+
+// Silinmiş olsa da item'ın güncel hali
+func currentItem(ctx context.Context, itemID int) (*models.TodoItem, error) {
+	item, err := getItemByID(ctx, itemID)
+	if err != nil {
+		item, err = getDeletedItemByID(ctx, itemID)
+	}
+	return item, err
+}
+
+// İki isteğe bağlı zaman aynı anı mı gösteriyor?
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"sync"
+	"testing"
+)
+
+func TestDeleteItemConcurrentOnlyOneSucceeds(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Bir kez silinmeli")
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if DeleteItem(context.Background(), item.ID, testUser) == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Fatalf("%d concurrent deletes succeeded, want 1", succeeded)
+	}
+	history, err := GetItemHistory(context.Background(), item.ID, testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deletes := 0
+	for _, version := range history {
+		if version.Action == "delete" {
+			deletes++
+		}
+	}
+	if deletes != 1 {
+		t.Fatalf("history has %d delete versions, want 1", deletes)
+	}
+}
+
+func TestAddItemToDeletedListFails(t *testing.T) {
+	list := newTestList(t, testUser)
+	if err := DeleteTodoList(context.Background(), list.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AddItemToList(context.Background(), list.ID, testUser, &models.TodoItem{Content: "x"}); err == nil || err.Error() != "list not found" {
+		t.Fatalf("err = %v, want list not found", err)
+	}
+}
+
+func TestUpdateItemReturnsCurrentState(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Eski")
+
+	updated, err := UpdateItem(context.Background(), item.ID, testUser, &models.TodoItem{Content: "Yeni"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Content != "Yeni" || item.Content != "Eski" {
+		t.Fatalf("updated = %q, original = %q", updated.Content, item.Content)
+	}
+}
+
+func TestUpdateItemValidatesContent(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Dolu")
+
+	if _, err := UpdateItem(context.Background(), item.ID, testUser, &models.TodoItem{Content: ""}); err == nil || err.Error() != "content is required" {
+		t.Fatalf("err = %v", err)
+	}
+}
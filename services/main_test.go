@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"os"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"testing"
+)
+
+// Testler mockdb'deki başlangıç verisiyle çalışır; event log bir kez oluşturulur
+func TestMain(m *testing.M) {
+	if err := repositories.BootstrapEventLog(); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+var (
+	testUser  = models.Actor{UserID: 1, Username: "user1", Role: "user"}
+	testAdmin = models.Actor{UserID: 2, Username: "admin1", Role: "admin"}
+)
+
+// Test için actor'a ait yeni bir liste oluşturur
+func newTestList(t *testing.T, actor models.Actor) *models.TodoList {
+	t.Helper()
+	list, err := CreateTodoList(context.Background(), actor, &models.TodoList{Name: "Test listesi"})
+	if err != nil {
+		t.Fatalf("CreateTodoList: %v", err)
+	}
+	return list
+}
+
+func newTestItem(t *testing.T, actor models.Actor, listID int, content string) *models.TodoItem {
+	t.Helper()
+	item, err := AddItemToList(context.Background(), listID, actor, &models.TodoItem{Content: content})
+	if err != nil {
+		t.Fatalf("AddItemToList: %v", err)
+	}
+	return item
+}
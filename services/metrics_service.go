@@ -0,0 +1,84 @@
+package services
+
+import (
+	"priviatodolist/metrics"
+	"priviatodolist/repositories"
+)
+
+// Giriş denemesi sonuçları
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+	LoginLocked  = "locked"
+)
+
+var loginAttempts = metrics.NewCounterVec("todo_login_attempts_total", "Login attempts by result.", "result")
+
+// Repository'ler üzerinden okuma anında hesaplanan alan metrikleri
+var (
+	_ = metrics.NewGaugeFunc("todo_sessions_active", "Sessions that are neither expired nor revoked.", func() float64 {
+		return float64(repositories.CountActiveSessions())
+	})
+	_ = metrics.NewGaugeVecFunc("todo_lists", "Todo lists by state.", func() []metrics.Sample {
+		stats := collectListStats()
+		return []metrics.Sample{
+			{LabelValues: []string{"active"}, Value: float64(stats.activeLists)},
+			{LabelValues: []string{"deleted"}, Value: float64(stats.deletedLists)},
+		}
+	}, "state")
+	_ = metrics.NewGaugeVecFunc("todo_items", "Todo items by state.", func() []metrics.Sample {
+		stats := collectListStats()
+		return []metrics.Sample{
+			{LabelValues: []string{"open"}, Value: float64(stats.openItems)},
+			{LabelValues: []string{"done"}, Value: float64(stats.doneItems)},
+			{LabelValues: []string{"deleted"}, Value: float64(stats.deletedItems)},
+		}
+	}, "state")
+	_ = metrics.NewGaugeFunc("todo_list_completion_average_percent", "Average completion of active lists, as computed by CalculateListCompletion.", func() float64 {
+		return collectListStats().averageCompletion
+	})
+)
+
+type listStats struct {
+	activeLists, deletedLists          int
+	openItems, doneItems, deletedItems int
+	averageCompletion                  float64
+}
+
+func collectListStats() listStats {
+	var stats listStats
+	lists, _ := repositories.GetAllTodoLists(true)
+	var completionSum float64
+	for _, list := range lists {
+		// Completion yalnızca silinmemiş item'lar üzerinden, listenin kopyasında hesaplanır
+		listCopy := *list
+		listCopy.Items = nil
+		for _, item := range list.Items {
+			switch {
+			case item.DeletedAt != nil:
+				stats.deletedItems++
+				continue
+			case item.IsDone:
+				stats.doneItems++
+			default:
+				stats.openItems++
+			}
+			listCopy.Items = append(listCopy.Items, item)
+		}
+		if list.DeletedAt != nil {
+			stats.deletedLists++
+			continue
+		}
+		stats.activeLists++
+		CalculateListCompletion(&listCopy)
+		completionSum += float64(listCopy.Completion)
+	}
+	if stats.activeLists > 0 {
+		stats.averageCompletion = completionSum / float64(stats.activeLists)
+	}
+	return stats
+}
+
+func recordLogin(result string) {
+	loginAttempts.Inc(result)
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Kullanıcının tüm verisini (profil, oturumlar, silinmişler dahil listeler ve item'lar, sürüm geçmişi,
+// webhook'lar ve kendisiyle ilgili denetim kayıtları) JSON dosyaları içeren bir zip arşivi olarak döner.
+func ExportMyData(ctx context.Context, actor models.Actor) ([]byte, error) {
+	ctx, span := startSpan(ctx, "ExportMyData")
+	defer span.End()
+
+	user, err := repositories.GetUserByID(actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, items, err := userListsAndItems(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := repositories.GetSessionsByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	hooks, err := repositories.GetWebhooksByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range hooks {
+		hook.Secret = ""
+	}
+
+	history := models.DataExportHistory{Lists: map[int][]*models.TodoListVersion{}, Items: map[int][]*models.TodoItemVersion{}}
+	for _, list := range lists {
+		history.Lists[list.ID], _ = getListVersions(ctx, list.ID)
+	}
+	for _, item := range items {
+		history.Items[item.ID], _ = getItemVersions(ctx, item.ID)
+	}
+
+	all, err := repositories.QueryAuditEvents(models.AuditFilter{})
+	if err != nil {
+		return nil, err
+	}
+	targets := userAuditTargets(user.ID, lists, items)
+	audit := []*models.AuditEvent{}
+	for _, event := range all {
+		if event.ActorID == user.ID || targets[event.TargetType][event.TargetID] {
+			audit = append(audit, event)
+		}
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", userSnapshot(user)},
+		{"sessions.json", sessions},
+		{"lists.json", lists},
+		{"items.json", items},
+		{"history.json", history},
+		{"webhooks.json", hooks},
+		{"audit.json", audit},
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return nil, err
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	RecordAudit(ctx, actor, "user.export", AuditTargetUser, user.ID, nil, nil)
+	return buf.Bytes(), nil
+}
+
+// Kullanıcının hesabını ve tüm verisini siler:
+// listeleri ve bu listelere ListID ile bağlı tüm item'ları kalıcı olarak siler, webhook'larını, oturumlarını ve
+// idempotency kayıtlarını kaldırır, event log ve denetim kayıtlarındaki kişisel bilgileri anonimleştirir.
+func DeleteMyAccount(ctx context.Context, actor models.Actor, password string) error {
+	ctx, span := startSpan(ctx, "DeleteMyAccount")
+	defer span.End()
+
+	if actor.ImpersonatorID != 0 {
+		return errors.New("forbidden")
+	}
+	user, err := repositories.GetUserByID(actor.UserID)
+	if err != nil {
+		return err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		return errors.New("invalid credentials")
+	}
+	if isLastAdmin(user) {
+		return errors.New("cannot delete the last admin")
+	}
+
+	lists, items, err := userListsAndItems(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	targets := userAuditTargets(user.ID, lists, items)
+
+	// Webhook'lar önce silinir; kalıcı silme event'leri dışarıya içerik taşımasın
+	hooks, err := repositories.GetWebhooksByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		if err := repositories.DeleteWebhook(hook.ID); err != nil {
+			return err
+		}
+	}
+
+	// Kalıcı silme yalnızca çöp kutusundaki kayıtlara uygulanır; aktif listeler item'larıyla birlikte önce silinir
+	for _, list := range lists {
+		if list.DeletedAt == nil {
+			if err := DeleteTodoList(ctx, list.ID, actor); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Item'lar listelerinden bağımsız olarak ListID üzerinden bulunup silinir
+	for _, item := range items {
+		if err := purgeItem(ctx, actor, item); err != nil {
+			return err
+		}
+	}
+	for _, list := range lists {
+		if err := purgeList(ctx, actor, list); err != nil {
+			return err
+		}
+	}
+
+	if err := repositories.DeleteUserSessions(user.ID); err != nil {
+		return err
+	}
+	closeUserStreams(user.ID)
+	repositories.DeleteIdempotencyRecordsByUserID(user.ID)
+	repositories.RedactUserEvents(user.ID)
+
+	anonymous := "deleted-user-" + strconv.Itoa(user.ID)
+	if err := repositories.DeleteUser(user.ID); err != nil {
+		return err
+	}
+
+	erased := models.Actor{UserID: user.ID, Username: anonymous, Role: actor.Role, RequestID: actor.RequestID}
+	RecordAudit(ctx, erased, "user.erase", AuditTargetUser, user.ID, nil, nil)
+	repositories.AnonymizeAuditEvents(user.ID, anonymous, targets)
+	return nil
+}
+
+// Kullanıcının silinmişler dahil listeleri (item'sız) ve bu listelere ListID ile bağlı tüm item'lar
+func userListsAndItems(ctx context.Context, userID int) ([]*models.TodoList, []*models.TodoItem, error) {
+	userLists, err := getTodoListsByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(userLists, func(i, j int) bool { return userLists[i].ID < userLists[j].ID })
+
+	lists := []*models.TodoList{}
+	items := []*models.TodoItem{}
+	for _, list := range userLists {
+		lists = append(lists, listSnapshot(list))
+		listItems, err := getItemsByListID(ctx, list.ID, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		sort.Slice(listItems, func(i, j int) bool { return listItems[i].ID < listItems[j].ID })
+		items = append(items, listItems...)
+	}
+	return lists, items, nil
+}
+
+// Denetim kayıtlarında kullanıcıyla ilgili hedefler (hedef türü -> ID)
+func userAuditTargets(userID int, lists []*models.TodoList, items []*models.TodoItem) map[string]map[int]bool {
+	targets := map[string]map[int]bool{
+		AuditTargetUser: {userID: true},
+		AuditTargetList: {},
+		AuditTargetItem: {},
+	}
+	for _, list := range lists {
+		targets[AuditTargetList][list.ID] = true
+	}
+	for _, item := range items {
+		targets[AuditTargetItem][item.ID] = true
+	}
+	return targets
+}
+
+// Kullanıcı, kullanıcıları yönetebilen son aktif hesap mı?
+func isLastAdmin(user *models.User) bool {
+	if !policy.HasPermission(user.Role, policy.UsersManage) {
+		return false
+	}
+	users, err := repositories.GetAllUsers()
+	if err != nil {
+		return true
+	}
+	var others []*models.User
+	for _, other := range users {
+		if other.ID != user.ID {
+			others = append(others, other)
+		}
+	}
+	return !hasActiveAdmin(others)
+}
+
+func hasActiveAdmin(users []*models.User) bool {
+	for _, user := range users {
+		if !user.Disabled && policy.HasPermission(user.Role, policy.UsersManage) {
+			return true
+		}
+	}
+	return false
+}
+
+// repositories.UpdateUserChecked için: güncellemeden sonra kullanıcıları yönetebilen aktif hesap kalmadıysa err döner
+func keepActiveAdmin(err error) func(users []*models.User) error {
+	return func(users []*models.User) error {
+		if !hasActiveAdmin(users) {
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Silme testleri başlangıç kullanıcılarını etkilemesin diye her test kendi kullanıcısını oluşturur
+func newTestUser(t *testing.T, username, password string) models.Actor {
+	t.Helper()
+	user, err := CreateUser(context.Background(), testAdmin, &models.UserCreate{Username: username, Password: password, Role: "user"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	return models.Actor{UserID: user.ID, Username: user.Username, Role: user.Role, IP: "192.0.2.10"}
+}
+
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestExportMyData(t *testing.T) {
+	actor := newTestUser(t, "exportuser", "secret")
+	list, _ := CreateTodoList(context.Background(), actor, &models.TodoList{Name: "Dışa aktarılacak"})
+	item := newTestItem(t, actor, list.ID, "kişisel not")
+	if err := DeleteItem(context.Background(), item.ID, actor); err != nil {
+		t.Fatal(err)
+	}
+	hook, err := repositories.CreateWebhook(&models.Webhook{UserID: actor.UserID, URL: "https://93.184.216.34/hook", Events: []string{"*"}, Secret: "gizli-anahtar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repositories.DeleteWebhook(hook.ID)
+	other := newTestList(t, testUser)
+
+	data, err := ExportMyData(context.Background(), actor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := readZip(t, data)
+	for _, name := range []string{"profile.json", "sessions.json", "lists.json", "items.json", "history.json", "webhooks.json", "audit.json"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("archive has no %s", name)
+		}
+	}
+
+	var items []models.TodoItem
+	if err := json.Unmarshal([]byte(files["items.json"]), &items); err != nil {
+		t.Fatal(err)
+	}
+	// Silinmiş item'lar da dışa aktarılır
+	if len(items) != 1 || items[0].Content != "kişisel not" || items[0].DeletedAt == nil {
+		t.Fatalf("items = %s", files["items.json"])
+	}
+	if strings.Contains(files["webhooks.json"], "gizli-anahtar") || strings.Contains(files["profile.json"], "$2a$") {
+		t.Error("archive contains a webhook secret or password hash")
+	}
+	if strings.Contains(files["lists.json"], `"id": `+strconv.Itoa(other.ID)+`,`) {
+		t.Error("archive contains another user's list")
+	}
+	if !strings.Contains(files["audit.json"], `"list.create"`) {
+		t.Error("archive has no audit events of the user")
+	}
+}
+
+func TestDeleteMyAccount(t *testing.T) {
+	actor := newTestUser(t, "eraseuser", "secret")
+	active, _ := CreateTodoList(context.Background(), actor, &models.TodoList{Name: "Aktif liste"})
+	newTestItem(t, actor, active.ID, "silinecek içerik")
+	trashed, _ := CreateTodoList(context.Background(), actor, &models.TodoList{Name: "Çöpteki liste"})
+	if err := DeleteTodoList(context.Background(), trashed.ID, actor); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteMyAccount(context.Background(), actor, "yanlış"); err == nil || err.Error() != "invalid credentials" {
+		t.Fatalf("wrong password: err = %v", err)
+	}
+	impersonated := actor
+	impersonated.ImpersonatorID = testAdmin.UserID
+	if err := DeleteMyAccount(context.Background(), impersonated, "secret"); err == nil || err.Error() != "forbidden" {
+		t.Fatalf("impersonated: err = %v", err)
+	}
+
+	if err := DeleteMyAccount(context.Background(), actor, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repositories.GetUserByID(actor.UserID); err == nil {
+		t.Fatal("user still exists")
+	}
+	for _, listID := range []int{active.ID, trashed.ID} {
+		if _, err := repositories.GetTodoListByID(listID); err == nil {
+			t.Errorf("list %d still exists", listID)
+		}
+	}
+
+	// Event log ve denetim kayıtlarında kişisel veri kalmaz
+	events, _ := repositories.GetEventsSince(0)
+	for _, event := range events {
+		if event.OwnerID == actor.UserID && (event.Content != "" || event.Name != "") {
+			t.Fatalf("event %d still has personal data: %+v", event.Seq, event)
+		}
+	}
+	audit, _ := repositories.QueryAuditEvents(models.AuditFilter{ActorID: actor.UserID})
+	if len(audit) == 0 {
+		t.Fatal("no audit events for the erased user")
+	}
+	for _, event := range audit {
+		if event.ActorUsername != "deleted-user-"+strconv.Itoa(actor.UserID) || event.IP != "" {
+			t.Fatalf("audit event not anonymized: %+v", event)
+		}
+		if event.Action == "item.create" && event.After != nil {
+			t.Fatalf("audit event keeps item content: %s", event.After)
+		}
+	}
+}
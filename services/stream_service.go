@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"sync"
+)
+
+// Her istemcinin bekleyebileceği en fazla event sayısı; dolarsa istemci düşürülür ve Last-Event-ID ile yeniden bağlanır
+const streamClientBuffer = 256
+
+type streamClient struct {
+	actor  models.Actor
+	events chan models.DomainEvent
+}
+
+var (
+	streamMu      sync.Mutex
+	streamClients = map[*streamClient]struct{}{}
+	streamStopped bool
+)
+
+// StartStreamBroker, event log'a eklenen event'leri bağlı stream istemcilerine dağıtır.
+// Dönen fonksiyon tüm istemci bağlantılarını kapatır.
+func StartStreamBroker() (stop func()) {
+	repositories.SubscribeEvents(func(event models.DomainEvent) {
+		streamMu.Lock()
+		defer streamMu.Unlock()
+
+		for client := range streamClients {
+			if !canSeeEvent(client.actor, event) {
+				continue
+			}
+			select {
+			case client.events <- event:
+			default:
+				// Yavaş istemci: bağlantıyı kapat, istemci kaldığı yerden devam eder
+				delete(streamClients, client)
+				close(client.events)
+			}
+		}
+	})
+
+	setWorkerRunning(WorkerStreamBroker, true)
+
+	return func() {
+		streamMu.Lock()
+		defer streamMu.Unlock()
+
+		setWorkerRunning(WorkerStreamBroker, false)
+		streamStopped = true
+		for client := range streamClients {
+			delete(streamClients, client)
+			close(client.events)
+		}
+	}
+}
+
+// Kullanıcının ve onun adına açılmış impersonation oturumlarının stream'lerini kapatır.
+// Oturumlar iptal edildiğinde çağrılır; istemci yeniden bağlanırken kimlik doğrulamadan geçemez.
+func closeUserStreams(userID int) {
+	streamMu.Lock()
+	defer streamMu.Unlock()
+
+	for client := range streamClients {
+		if client.actor.UserID == userID || client.actor.ImpersonatorID == userID {
+			delete(streamClients, client)
+			close(client.events)
+		}
+	}
+}
+
+// Kullanıcı event'in ait olduğu listeyi okuyabiliyor mu?
+func canSeeEvent(actor models.Actor, event models.DomainEvent) bool {
+	return policy.CanAccess(actor.Role, actor.UserID, event.OwnerID, policy.ResourceLists, policy.ActionRead)
+}
+
+// SubscribeStream, kullanıcının erişebildiği listelerdeki event'leri sırayla döner.
+// lastSeq > 0 ise önce bu sıra numarasından sonraki kaçırılmış event'ler gönderilir.
+// Kanal kapandığında istemci yeniden bağlanmalıdır; cancel aboneliği sonlandırır.
+func SubscribeStream(ctx context.Context, actor models.Actor, lastSeq int64) (<-chan models.DomainEvent, func(), error) {
+	ctx, span := startSpan(ctx, "SubscribeStream")
+	defer span.End()
+
+	client := &streamClient{actor: actor, events: make(chan models.DomainEvent, streamClientBuffer)}
+
+	streamMu.Lock()
+	if streamStopped {
+		streamMu.Unlock()
+		return nil, nil, errors.New("stream closed")
+	}
+	streamClients[client] = struct{}{}
+	streamMu.Unlock()
+
+	done := make(chan struct{})
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			close(done)
+			streamMu.Lock()
+			defer streamMu.Unlock()
+			if _, ok := streamClients[client]; ok {
+				delete(streamClients, client)
+				close(client.events)
+			}
+		})
+	}
+
+	if lastSeq <= 0 {
+		return client.events, cancel, nil
+	}
+
+	// Abone olduktan sonra geçmişi okuyup canlı akışla birleştir; aynı event iki kez gönderilmez
+	backlog, err := getEventsSince(ctx, lastSeq)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	out := make(chan models.DomainEvent, streamClientBuffer)
+	go func() {
+		defer close(out)
+		send := func(event models.DomainEvent) bool {
+			select {
+			case out <- event:
+				return true
+			case <-done:
+				return false
+			}
+		}
+
+		sent := lastSeq
+		for _, event := range backlog {
+			if canSeeEvent(actor, *event) && !send(*event) {
+				return
+			}
+			sent = event.Seq
+		}
+		for event := range client.events {
+			if event.Seq > sent && !send(event) {
+				return
+			}
+		}
+	}()
+	return out, cancel, nil
+}
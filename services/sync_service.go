@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sync token'ı event sıra numarasını saklayan opak bir değerdir
+func encodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("v1:" + strconv.FormatInt(seq, 10)))
+}
+
+func decodeSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), "v1:") {
+		return 0, errors.New("invalid sync token")
+	}
+	seq, err := strconv.ParseInt(strings.TrimPrefix(string(raw), "v1:"), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, errors.New("invalid sync token")
+	}
+	return seq, nil
+}
+
+// Kullanıcının kendi listelerinde token'dan sonraki değişiklikleri getir; boş token tüm veriyi döner
+func GetSyncChanges(ctx context.Context, actor models.Actor, token string) (*models.SyncChanges, error) {
+	ctx, span := startSpan(ctx, "GetSyncChanges")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.ListsReadOwn) {
+		return nil, errors.New("forbidden")
+	}
+	since, err := decodeSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	last := repositories.GetLastEventSeq()
+	events, err := getEventsSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &models.SyncChanges{
+		Lists:       []*models.TodoList{},
+		Items:       []*models.TodoItem{},
+		PurgedLists: []int{},
+		PurgedItems: []int{},
+	}
+	lists := map[int]bool{}
+	items := map[int]bool{}
+	for _, event := range events {
+		if event.Seq > last {
+			break
+		}
+		if event.OwnerID != actor.UserID {
+			continue
+		}
+		switch event.Type {
+		case models.EventListPurged:
+			lists[event.ListID] = false
+			changes.PurgedLists = append(changes.PurgedLists, event.ListID)
+		case models.EventItemPurged:
+			items[event.ItemID] = false
+			changes.PurgedItems = append(changes.PurgedItems, event.ItemID)
+		case models.EventListCreated, models.EventListRenamed, models.EventListDeleted, models.EventListRestored:
+			lists[event.ListID] = true
+			if event.Type == models.EventListDeleted || event.Type == models.EventListRestored {
+				markListItems(ctx, items, event.ListID)
+			}
+		default:
+			items[event.ItemID] = true
+		}
+	}
+
+	for _, listID := range sortedIDs(lists) {
+		if list, err := getTodoListByID(ctx, listID); err == nil {
+			changes.Lists = append(changes.Lists, listSnapshot(list))
+		}
+	}
+	for _, itemID := range sortedIDs(items) {
+		if item, err := currentItem(ctx, itemID); err == nil {
+			changes.Items = append(changes.Items, itemSnapshot(item))
+		}
+	}
+
+	changes.Token = encodeSyncToken(last)
+	return changes, nil
+}
+
+// İstemci değişikliklerini uygular; her kayıt için ayrı sonuç döner.
+// Değişiklikler normal servis fonksiyonlarıyla uygulandığı için event, denetim ve webhook kayıtları oluşur.
+func PushSyncChanges(ctx context.Context, actor models.Actor, push *models.SyncPush) (*models.SyncPushResult, error) {
+	ctx, span := startSpan(ctx, "PushSyncChanges")
+	defer span.End()
+
+	strategy := push.Strategy
+	if strategy == "" {
+		strategy = models.SyncLastWriterWins
+	}
+	if strategy != models.SyncLastWriterWins && strategy != models.SyncReject {
+		return nil, errors.New("unknown strategy")
+	}
+	since, err := decodeSyncToken(push.Token)
+	if err != nil {
+		return nil, err
+	}
+	if strategy == models.SyncReject && push.Token == "" {
+		return nil, errors.New("token is required for reject strategy")
+	}
+
+	// Token'dan sonra sunucuda değişmiş kayıtlar (reject stratejisi için)
+	changedLists := map[int]bool{}
+	changedItems := map[int]bool{}
+	events, err := getEventsSince(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.ItemID != 0 {
+			changedItems[event.ItemID] = true
+			continue
+		}
+		changedLists[event.ListID] = true
+		if event.Type == models.EventListDeleted || event.Type == models.EventListRestored {
+			markListItems(ctx, changedItems, event.ListID)
+		}
+	}
+
+	result := &models.SyncPushResult{Results: []models.SyncResult{}}
+	createdLists := map[string]int{}
+
+	for _, change := range push.Lists {
+		r := pushListChange(ctx, actor, change, strategy, changedLists)
+		if r.Status == models.SyncApplied && change.ID == 0 && change.ClientID != "" {
+			createdLists[change.ClientID] = r.ID
+		}
+		result.Results = append(result.Results, r)
+	}
+	for _, change := range push.Items {
+		if change.ListID == 0 && change.ListClientID != "" {
+			change.ListID = createdLists[change.ListClientID]
+		}
+		result.Results = append(result.Results, pushItemChange(ctx, actor, change, strategy, changedItems))
+	}
+
+	result.Token = encodeSyncToken(repositories.GetLastEventSeq())
+	return result, nil
+}
+
+func pushListChange(ctx context.Context, actor models.Actor, change models.SyncListChange, strategy string, changed map[int]bool) models.SyncResult {
+	r := models.SyncResult{Type: "list", ClientID: change.ClientID, ID: change.ID}
+
+	if change.ID == 0 {
+		if change.Deleted {
+			r.Status = models.SyncUnchanged
+			return r
+		}
+		list, err := CreateTodoList(ctx, actor, &models.TodoList{Name: change.Name})
+		if err != nil {
+			return rejectSync(r, err)
+		}
+		r.ID, r.Status, r.List = list.ID, models.SyncApplied, listSnapshot(list)
+		return r
+	}
+
+	if change.UpdatedAt.IsZero() {
+		return rejectSync(r, errors.New("updated_at is required"))
+	}
+	server, err := getTodoListByID(ctx, change.ID)
+	if err != nil || !policy.CanAccess(actor.Role, actor.UserID, server.UserID, policy.ResourceLists, policy.ActionWrite) {
+		return rejectSync(r, errors.New("list not found"))
+	}
+	if syncConflict(strategy, changed[change.ID], change.UpdatedAt, server.UpdatedAt) {
+		r.Status, r.List = models.SyncConflict, listSnapshot(server)
+		return r
+	}
+
+	serverDeleted := server.DeletedAt != nil
+	switch {
+	case change.Deleted && !serverDeleted:
+		err = DeleteTodoList(ctx, change.ID, actor)
+	case !change.Deleted && serverDeleted:
+		_, err = RestoreTodoList(ctx, change.ID, actor)
+	}
+	if err == nil && !change.Deleted && change.Name != server.Name {
+		_, err = UpdateTodoList(ctx, change.ID, actor, &models.TodoList{Name: change.Name})
+	}
+	if err == nil {
+		server, err = getTodoListByID(ctx, change.ID)
+	}
+	if err != nil {
+		return rejectSync(r, err)
+	}
+
+	r.Status, r.List = models.SyncApplied, listSnapshot(server)
+	return r
+}
+
+func pushItemChange(ctx context.Context, actor models.Actor, change models.SyncItemChange, strategy string, changed map[int]bool) models.SyncResult {
+	r := models.SyncResult{Type: "item", ClientID: change.ClientID, ID: change.ID}
+
+	update := &models.TodoItem{Content: change.Content, IsDone: change.IsDone, DueDate: change.DueDate}
+	if !change.Deleted {
+		if err := ValidateItem(update); err != nil {
+			return rejectSync(r, err)
+		}
+	}
+
+	if change.ID == 0 {
+		if change.Deleted {
+			r.Status = models.SyncUnchanged
+			return r
+		}
+		item, err := AddItemToList(ctx, change.ListID, actor, update)
+		if err != nil {
+			return rejectSync(r, err)
+		}
+		r.ID, r.Status, r.Item = item.ID, models.SyncApplied, itemSnapshot(item)
+		return r
+	}
+
+	if change.UpdatedAt.IsZero() {
+		return rejectSync(r, errors.New("updated_at is required"))
+	}
+	server, err := currentItem(ctx, change.ID)
+	if err != nil || !canAccessList(ctx, actor, server.ListID, policy.ResourceItems, policy.ActionWrite) {
+		return rejectSync(r, errors.New("item not found"))
+	}
+	if syncConflict(strategy, changed[change.ID], change.UpdatedAt, server.UpdatedAt) {
+		r.Status, r.Item = models.SyncConflict, itemSnapshot(server)
+		return r
+	}
+
+	serverDeleted := server.DeletedAt != nil
+	switch {
+	case change.Deleted && !serverDeleted:
+		err = DeleteItem(ctx, change.ID, actor)
+	case !change.Deleted && serverDeleted:
+		_, err = RestoreItem(ctx, change.ID, actor)
+	}
+	if err == nil && !change.Deleted {
+		_, err = UpdateItem(ctx, change.ID, actor, update)
+	}
+	if err == nil {
+		server, err = currentItem(ctx, change.ID)
+	}
+	if err != nil {
+		return rejectSync(r, err)
+	}
+
+	r.Status, r.Item = models.SyncApplied, itemSnapshot(server)
+	return r
+}
+
+// Liste silme/geri yükleme event'i item ID'si taşımaz ama listenin tüm item'larını etkiler
+func markListItems(ctx context.Context, items map[int]bool, listID int) {
+	listItems, err := getItemsByListID(ctx, listID, true)
+	if err != nil {
+		return
+	}
+	for _, item := range listItems {
+		items[item.ID] = true
+	}
+}
+
+// lww: istemcinin değişikliği sunucudakinden eski ise çakışma.
+// reject: kayıt token'dan sonra sunucuda değişmişse çakışma.
+func syncConflict(strategy string, changedSinceToken bool, clientUpdatedAt, serverUpdatedAt time.Time) bool {
+	if strategy == models.SyncReject {
+		return changedSinceToken
+	}
+	return clientUpdatedAt.Before(serverUpdatedAt)
+}
+
+func rejectSync(r models.SyncResult, err error) models.SyncResult {
+	r.Status = models.SyncRejected
+	r.Error = err.Error()
+	return r
+}
+
+func sortedIDs(ids map[int]bool) []int {
+	result := make([]int, 0, len(ids))
+	for id := range ids {
+		result = append(result, id)
+	}
+	sort.Ints(result)
+	return result
+}
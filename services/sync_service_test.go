@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"testing"
+	"time"
+)
+
+func pushOneItem(t *testing.T, strategy, token string, change models.SyncItemChange) models.SyncResult {
+	t.Helper()
+	result, err := PushSyncChanges(context.Background(), testUser, &models.SyncPush{Token: token, Strategy: strategy, Items: []models.SyncItemChange{change}})
+	if err != nil {
+		t.Fatalf("PushSyncChanges: %v", err)
+	}
+	return result.Results[0]
+}
+
+func TestSyncLastWriterWins(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Sunucu")
+
+	stale := pushOneItem(t, models.SyncLastWriterWins, "", models.SyncItemChange{
+		ID: item.ID, Content: "Eski istemci", UpdatedAt: item.UpdatedAt.Add(-time.Minute),
+	})
+	if stale.Status != models.SyncConflict || stale.Item.Content != "Sunucu" {
+		t.Fatalf("stale change: %+v", stale)
+	}
+
+	fresh := pushOneItem(t, models.SyncLastWriterWins, "", models.SyncItemChange{
+		ID: item.ID, Content: "Yeni istemci", UpdatedAt: time.Now().Add(time.Minute),
+	})
+	if fresh.Status != models.SyncApplied || fresh.Item.Content != "Yeni istemci" {
+		t.Fatalf("fresh change: %+v", fresh)
+	}
+}
+
+func TestSyncRejectStrategyUsesToken(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "İlk")
+	changes, err := GetSyncChanges(context.Background(), testUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Token alındıktan sonra sunucuda değişen kayıt reddedilir
+	if _, err := UpdateItem(context.Background(), item.ID, testUser, &models.TodoItem{Content: "Sunucuda değişti"}); err != nil {
+		t.Fatal(err)
+	}
+	r := pushOneItem(t, models.SyncReject, changes.Token, models.SyncItemChange{
+		ID: item.ID, Content: "İstemci", UpdatedAt: time.Now().Add(time.Hour),
+	})
+	if r.Status != models.SyncConflict {
+		t.Fatalf("status = %s, want conflict", r.Status)
+	}
+
+	// Delta yalnızca token'dan sonra değişen item'ı içerir
+	delta, err := GetSyncChanges(context.Background(), testUser, changes.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delta.Items) != 1 || delta.Items[0].ID != item.ID || len(delta.Lists) != 0 {
+		t.Fatalf("delta = %d lists, %d items", len(delta.Lists), len(delta.Items))
+	}
+}
+
+func TestSyncRejectsInvalidChanges(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Geçerli")
+
+	tests := []struct {
+		name   string
+		change models.SyncItemChange
+		err    string
+	}{
+		{"blank content", models.SyncItemChange{ID: item.ID, Content: "  ", UpdatedAt: time.Now()}, "content is required"},
+		{"blank new item", models.SyncItemChange{ListID: list.ID, Content: ""}, "content is required"},
+		{"zero updated_at", models.SyncItemChange{ID: item.ID, Content: "x"}, "updated_at is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := pushOneItem(t, models.SyncLastWriterWins, "", tt.change)
+			if r.Status != models.SyncRejected || r.Error != tt.err {
+				t.Fatalf("result = %+v, want rejected %q", r, tt.err)
+			}
+		})
+	}
+
+	if current, _ := currentItem(context.Background(), item.ID); current.Content != "Geçerli" {
+		t.Fatalf("content changed to %q", current.Content)
+	}
+}
+
+// Token'dan sonra listesi silinen item'ın event'i yoktur ama yine de değişmiş sayılır
+func TestSyncRejectStrategyDetectsDeletedList(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "İlk")
+	changes, err := GetSyncChanges(context.Background(), testUser, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DeleteTodoList(context.Background(), list.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	r := pushOneItem(t, models.SyncReject, changes.Token, models.SyncItemChange{
+		ID: item.ID, Content: "İstemci", UpdatedAt: time.Now().Add(time.Hour),
+	})
+	if r.Status != models.SyncConflict || r.Item == nil || r.Item.DeletedAt == nil {
+		t.Fatalf("result = %+v, want conflict with the deleted item", r)
+	}
+	if current, _ := currentItem(context.Background(), item.ID); current.Content != "İlk" || current.DeletedAt == nil {
+		t.Fatalf("item = %+v", current)
+	}
+}
@@ -1,32 +1,42 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"priviatodolist/models"
-	"priviatodolist/repositories"
-	"time"
+	"priviatodolist/policy"
 )
 
-// Yardımcı fonksiyon: Kullanıcı verilen listeye sahip mi?
-func ownsList(userID, listID int) bool {
-	list, err := repositories.GetTodoListByID(listID)
+// Yardımcı fonksiyon: Kullanıcı verilen liste üzerinde bu eylemi yapabilir mi?
+func canAccessList(ctx context.Context, actor models.Actor, listID int, resource, action string) bool {
+	list, err := getTodoListByID(ctx, listID)
 	if err != nil {
 		return false
 	}
-	return list.UserID == userID
+	return policy.CanAccess(actor.Role, actor.UserID, list.UserID, resource, action)
 }
 
-func CreateTodoList(userID int, newList *models.TodoList) (*models.TodoList, error) {
+func CreateTodoList(ctx context.Context, actor models.Actor, newList *models.TodoList) (*models.TodoList, error) {
+	ctx, span := startSpan(ctx, "CreateTodoList")
+	defer span.End()
+
 	if len(newList.Name) < 3 {
 		return nil, errors.New("title must be at least 3 characters")
 	}
+	if !policy.HasPermission(actor.Role, policy.ListsWriteOwn) {
+		return nil, errors.New("unauthorized")
+	}
 
-	// Kullanıcının listesine ait olmayan bir liste yaratılmasını engelle
-	newList.UserID = userID
-	newList.CreatedAt = time.Now()
-	newList.UpdatedAt = time.Now()
-
-	createdList, err := repositories.CreateTodoList(newList)
+	// Liste her zaman isteği yapan kullanıcıya ait olarak oluşturulur
+	event, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventListCreated,
+		OwnerID: actor.UserID,
+		Name:    newList.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	createdList, err := getTodoListByID(ctx, event.ListID)
 	if err != nil {
 		return nil, err
 	}
@@ -34,69 +44,87 @@ func CreateTodoList(userID int, newList *models.TodoList) (*models.TodoList, err
 	// Completion oranını hesapla
 	CalculateListCompletion(createdList)
 
+	RecordAudit(ctx, actor, "list.create", AuditTargetList, createdList.ID, nil, listSnapshot(createdList))
 	return createdList, nil
 }
 
-func UpdateTodoList(listID int, userID int, updatedList *models.TodoList) (*models.TodoList, error) {
-	list, err := repositories.GetTodoListByID(listID)
+func UpdateTodoList(ctx context.Context, listID int, actor models.Actor, updatedList *models.TodoList) (*models.TodoList, error) {
+	ctx, span := startSpan(ctx, "UpdateTodoList")
+	defer span.End()
+
+	list, err := getTodoListByID(ctx, listID)
 	if err != nil {
 		return nil, errors.New("list not found")
 	}
 
-	// Kullanıcının sadece kendi listelerini güncellemesine izin veriyoruz
-	if !ownsList(userID, listID) {
+	// Kullanıcının sadece yetkili olduğu listeleri güncellemesine izin veriyoruz
+	if !canAccessList(ctx, actor, listID, policy.ResourceLists, policy.ActionWrite) {
 		return nil, errors.New("unauthorized")
 	}
 
-	list.Name = updatedList.Name
-	list.UpdatedAt = time.Now()
+	before := listSnapshot(list)
+	if updatedList.Name != list.Name {
+		if _, err := emit(ctx, actor, &models.DomainEvent{
+			Type:    models.EventListRenamed,
+			OwnerID: list.UserID,
+			ListID:  listID,
+			Name:    updatedList.Name,
+		}); err != nil {
+			return nil, err
+		}
+		if list, err = getTodoListByID(ctx, listID); err != nil {
+			return nil, err
+		}
+		RecordAudit(ctx, actor, "list.update", AuditTargetList, listID, before, listSnapshot(list))
+	}
 
 	// Completion oranını tekrar hesapla
 	CalculateListCompletion(list)
 
-	updatedList, err = repositories.UpdateTodoList(listID, list)
-	if err != nil {
-		return nil, err
-	}
-
-	return updatedList, nil
+	return list, nil
 }
 
 // Todo listesini sil (soft delete)
-func DeleteTodoList(listID int, userID int) error {
-	list, err := repositories.GetTodoListByID(listID)
-	if err != nil {
+func DeleteTodoList(ctx context.Context, listID int, actor models.Actor) error {
+	ctx, span := startSpan(ctx, "DeleteTodoList")
+	defer span.End()
+
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil || list.DeletedAt != nil {
 		return errors.New("list not found")
 	}
 
-	// Kullanıcının sadece kendi listelerini silmesine izin veriyoruz
-	if !ownsList(userID, listID) {
+	// Kullanıcının sadece yetkili olduğu listeleri silmesine izin veriyoruz
+	if !canAccessList(ctx, actor, listID, policy.ResourceLists, policy.ActionWrite) {
 		return errors.New("unauthorized")
 	}
 
-	now := time.Now()
-	list.DeletedAt = &now
-	list.UpdatedAt = now
-
-	// Listedeki tüm item'ları da sil
-	for _, item := range list.Items {
-		if item.DeletedAt == nil { // Zaten silinmemiş item'ları sil
-			item.DeletedAt = &now
-			item.UpdatedAt = now
-			_, err := repositories.UpdateItem(item.ID, item)
-			if err != nil {
-				return err
-			}
-		}
+	// Listedeki silinmemiş tüm item'lar da aynı event ile silinir
+	before := listSnapshot(list)
+	if _, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventListDeleted,
+		OwnerID: list.UserID,
+		ListID:  listID,
+	}); err != nil {
+		return err
+	}
+	if list, err = getTodoListByID(ctx, listID); err != nil {
+		return err
 	}
 
-	_, err = repositories.UpdateTodoList(listID, list)
-	return err
+	RecordAudit(ctx, actor, "list.delete", AuditTargetList, listID, before, listSnapshot(list))
+	return nil
 }
 
 // Kullanıcıya ait tüm aktif todo listelerini getir
-func GetMyTodoLists(userID int) ([]*models.TodoList, error) {
-	lists, err := repositories.GetTodoListsByUserID(userID, false)
+func GetMyTodoLists(ctx context.Context, actor models.Actor) ([]*models.TodoList, error) {
+	ctx, span := startSpan(ctx, "GetMyTodoLists")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.ListsReadOwn) {
+		return nil, errors.New("forbidden")
+	}
+	lists, err := getTodoListsByUserID(ctx, actor.UserID, false)
 	if err != nil {
 		return nil, err
 	}
@@ -109,8 +137,14 @@ func GetMyTodoLists(userID int) ([]*models.TodoList, error) {
 }
 
 // Admin için: Silinmiş dahil tüm todo listelerini getir
-func GetAllTodoListsForAdmin() ([]*models.TodoList, error) {
-	lists, err := repositories.GetAllTodoLists(true)
+func GetAllTodoListsForAdmin(ctx context.Context, actor models.Actor) ([]*models.TodoList, error) {
+	ctx, span := startSpan(ctx, "GetAllTodoListsForAdmin")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.ListsReadAny) {
+		return nil, errors.New("forbidden")
+	}
+	lists, err := getAllTodoLists(ctx, true)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"priviatodolist/tracing"
+)
+
+// Servis çağrısı için ctx'in altında span açar ve span'ı taşıyan context'i döner;
+// bu context'le yapılan servis ve depolama çağrılarının span'ları bunun altına eklenir.
+// Kullanım:
+//
+//	ctx, span := startSpan(ctx, "CreateTodoList")
+//	defer span.End()
+func startSpan(ctx context.Context, name string) (context.Context, *tracing.Span) {
+	return tracing.Start(ctx, "services."+name)
+}
+
+// Depolama çağrısı için span
+func startRepositorySpan(ctx context.Context, name string) *tracing.Span {
+	_, span := tracing.Start(ctx, "repositories."+name)
+	return span
+}
+
+// Depolama okumasını span içinde yapar; okumalar event kilidini beklediği için yazmalar gibi izlenir
+func traceRead[T any](ctx context.Context, name string, read func() (T, error)) (T, error) {
+	span := startRepositorySpan(ctx, name)
+	defer span.End()
+
+	result, err := read()
+	span.SetError(err)
+	return result, err
+}
+
+func getTodoListByID(ctx context.Context, listID int) (*models.TodoList, error) {
+	return traceRead(ctx, "GetTodoListByID", func() (*models.TodoList, error) {
+		return repositories.GetTodoListByID(listID)
+	})
+}
+
+func getTodoListsByUserID(ctx context.Context, userID int, includeDeleted bool) ([]*models.TodoList, error) {
+	return traceRead(ctx, "GetTodoListsByUserID", func() ([]*models.TodoList, error) {
+		return repositories.GetTodoListsByUserID(userID, includeDeleted)
+	})
+}
+
+func getAllTodoLists(ctx context.Context, includeDeleted bool) ([]*models.TodoList, error) {
+	return traceRead(ctx, "GetAllTodoLists", func() ([]*models.TodoList, error) {
+		return repositories.GetAllTodoLists(includeDeleted)
+	})
+}
+
+func getDeletedTodoListsByUserID(ctx context.Context, userID int) ([]*models.TodoList, error) {
+	return traceRead(ctx, "GetDeletedTodoListsByUserID", func() ([]*models.TodoList, error) {
+		return repositories.GetDeletedTodoListsByUserID(userID)
+	})
+}
+
+func getItemByID(ctx context.Context, itemID int) (*models.TodoItem, error) {
+	return traceRead(ctx, "GetItemByID", func() (*models.TodoItem, error) {
+		return repositories.GetItemByID(itemID)
+	})
+}
+
+func getDeletedItemByID(ctx context.Context, itemID int) (*models.TodoItem, error) {
+	return traceRead(ctx, "GetDeletedItemByID", func() (*models.TodoItem, error) {
+		return repositories.GetDeletedItemByID(itemID)
+	})
+}
+
+func getItemsByListID(ctx context.Context, listID int, includeDeleted bool) ([]*models.TodoItem, error) {
+	return traceRead(ctx, "GetItemsByListID", func() ([]*models.TodoItem, error) {
+		return repositories.GetItemsByListID(listID, includeDeleted)
+	})
+}
+
+func getDeletedItemsByUserID(ctx context.Context, userID int) ([]*models.TodoItem, error) {
+	return traceRead(ctx, "GetDeletedItemsByUserID", func() ([]*models.TodoItem, error) {
+		return repositories.GetDeletedItemsByUserID(userID)
+	})
+}
+
+func getItemVersions(ctx context.Context, itemID int) ([]*models.TodoItemVersion, error) {
+	return traceRead(ctx, "GetItemVersions", func() ([]*models.TodoItemVersion, error) {
+		return repositories.GetItemVersions(itemID)
+	})
+}
+
+func getItemVersion(ctx context.Context, itemID, version int) (*models.TodoItemVersion, error) {
+	return traceRead(ctx, "GetItemVersion", func() (*models.TodoItemVersion, error) {
+		return repositories.GetItemVersion(itemID, version)
+	})
+}
+
+func getItemVersionsByListID(ctx context.Context, listID int) ([]*models.TodoItemVersion, error) {
+	return traceRead(ctx, "GetItemVersionsByListID", func() ([]*models.TodoItemVersion, error) {
+		return repositories.GetItemVersionsByListID(listID)
+	})
+}
+
+func getListVersions(ctx context.Context, listID int) ([]*models.TodoListVersion, error) {
+	return traceRead(ctx, "GetListVersions", func() ([]*models.TodoListVersion, error) {
+		return repositories.GetListVersions(listID)
+	})
+}
+
+func getEventsSince(ctx context.Context, seq int64) ([]*models.DomainEvent, error) {
+	return traceRead(ctx, "GetEventsSince", func() ([]*models.DomainEvent, error) {
+		return repositories.GetEventsSince(seq)
+	})
+}
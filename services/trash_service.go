@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"time"
+)
+
+// Kullanıcının çöp kutusunu getir
+func GetTrash(ctx context.Context, actor models.Actor) (*models.Trash, error) {
+	ctx, span := startSpan(ctx, "GetTrash")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.ListsReadOwn) {
+		return nil, errors.New("forbidden")
+	}
+	lists, err := getDeletedTodoListsByUserID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := getDeletedItemsByUserID(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Trash{Lists: lists, Items: items}, nil
+}
+
+// Silinmiş listeyi ve listeyle birlikte silinen item'ları geri yükle
+func RestoreTodoList(ctx context.Context, listID int, actor models.Actor) (*models.TodoList, error) {
+	ctx, span := startSpan(ctx, "RestoreTodoList")
+	defer span.End()
+
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil || list.DeletedAt == nil {
+		return nil, errors.New("list not found in trash")
+	}
+	if !canAccessList(ctx, actor, listID, policy.ResourceLists, policy.ActionWrite) {
+		return nil, errors.New("unauthorized")
+	}
+
+	// Listeyle birlikte silinen item'lar da aynı event ile geri yüklenir
+	before := listSnapshot(list)
+	if _, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventListRestored,
+		OwnerID: list.UserID,
+		ListID:  listID,
+	}); err != nil {
+		return nil, err
+	}
+	if list, err = getTodoListByID(ctx, listID); err != nil {
+		return nil, err
+	}
+
+	CalculateListCompletion(list)
+	RecordAudit(ctx, actor, "list.restore", AuditTargetList, listID, before, listSnapshot(list))
+	return list, nil
+}
+
+// Silinmiş item'ı geri yükle; listesi silinmişse önce liste geri yüklenmelidir
+func RestoreItem(ctx context.Context, itemID int, actor models.Actor) (*models.TodoItem, error) {
+	ctx, span := startSpan(ctx, "RestoreItem")
+	defer span.End()
+
+	item, err := getDeletedItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccessList(ctx, actor, item.ListID, policy.ResourceItems, policy.ActionWrite) {
+		return nil, errors.New("unauthorized")
+	}
+	list, err := getTodoListByID(ctx, item.ListID)
+	if err != nil {
+		return nil, err
+	}
+	if list.DeletedAt != nil {
+		return nil, errors.New("list is deleted")
+	}
+
+	before := itemSnapshot(item)
+	if _, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventItemRestored,
+		OwnerID: list.UserID,
+		ListID:  item.ListID,
+		ItemID:  itemID,
+	}); err != nil {
+		return nil, err
+	}
+	if item, err = getItemByID(ctx, itemID); err != nil {
+		return nil, err
+	}
+
+	RecordAudit(ctx, actor, "item.restore", AuditTargetItem, itemID, before, itemSnapshot(item))
+	return item, nil
+}
+
+// Admin için: çöp kutusundaki listeyi kalıcı olarak sil
+func PurgeTodoList(ctx context.Context, listID int, actor models.Actor) error {
+	ctx, span := startSpan(ctx, "PurgeTodoList")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.TrashPurge) {
+		return errors.New("forbidden")
+	}
+	list, err := getTodoListByID(ctx, listID)
+	if err != nil || list.DeletedAt == nil {
+		return errors.New("list not found in trash")
+	}
+
+	return purgeList(ctx, actor, list)
+}
+
+// Kalıcı silinen kaydın içeriği denetim kaydında da tutulmaz; yalnızca kimlik bilgileri yazılır
+func purgeList(ctx context.Context, actor models.Actor, list *models.TodoList) error {
+	before := map[string]interface{}{"id": list.ID, "owner_id": list.UserID}
+	if _, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventListPurged,
+		OwnerID: list.UserID,
+		ListID:  list.ID,
+	}); err != nil {
+		return err
+	}
+
+	RecordAudit(ctx, actor, "list.purge", AuditTargetList, list.ID, before, nil)
+	return nil
+}
+
+// Admin için: çöp kutusundaki item'ı kalıcı olarak sil
+func PurgeItem(ctx context.Context, itemID int, actor models.Actor) error {
+	ctx, span := startSpan(ctx, "PurgeItem")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.TrashPurge) {
+		return errors.New("forbidden")
+	}
+	item, err := getDeletedItemByID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+
+	return purgeItem(ctx, actor, item)
+}
+
+func purgeItem(ctx context.Context, actor models.Actor, item *models.TodoItem) error {
+	ownerID := 0
+	if list, err := getTodoListByID(ctx, item.ListID); err == nil {
+		ownerID = list.UserID
+	}
+
+	before := map[string]interface{}{"id": item.ID, "list_id": item.ListID}
+	if _, err := emit(ctx, actor, &models.DomainEvent{
+		Type:    models.EventItemPurged,
+		OwnerID: ownerID,
+		ListID:  item.ListID,
+		ItemID:  item.ID,
+	}); err != nil {
+		return err
+	}
+
+	RecordAudit(ctx, actor, "item.purge", AuditTargetItem, item.ID, before, nil)
+	return nil
+}
+
+// Admin için: verilen süreden daha önce silinmiş tüm kayıtları kalıcı olarak sil
+func PurgeTrash(ctx context.Context, actor models.Actor, olderThan time.Duration) (*models.PurgeResult, error) {
+	ctx, span := startSpan(ctx, "PurgeTrash")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.TrashPurge) {
+		return nil, errors.New("forbidden")
+	}
+	return purgeDeletedBefore(ctx, actor, time.Now().Add(-olderThan))
+}
+
+func purgeDeletedBefore(ctx context.Context, actor models.Actor, cutoff time.Time) (*models.PurgeResult, error) {
+	result := &models.PurgeResult{}
+
+	lists, err := repositories.GetTodoListsDeletedBefore(cutoff)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		if err := purgeList(ctx, actor, list); err != nil {
+			return nil, err
+		}
+		result.Lists++
+	}
+
+	// Listeler silinirken item'ları da gitti; kalanlar tek tek silinmiş item'lar
+	items, err := repositories.GetItemsDeletedBefore(cutoff)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err := purgeItem(ctx, actor, item); err != nil {
+			return nil, err
+		}
+		result.Items++
+	}
+
+	return result, nil
+}
+
+// StartTrashRetention, retention süresinden eski çöp kutusu kayıtlarını her interval'de kalıcı olarak siler.
+// Dönen fonksiyon job'u durdurur.
+func StartTrashRetention(retention, interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	setWorkerRunning(WorkerTrashRetention, true)
+
+	go func() {
+		defer setWorkerRunning(WorkerTrashRetention, false)
+		for {
+			select {
+			case <-ticker.C:
+				result, err := purgeDeletedBefore(context.Background(), systemActor, time.Now().Add(-retention))
+				if err != nil {
+					slog.Error("trash retention failed", "error", err)
+					continue
+				}
+				if result.Lists > 0 || result.Items > 0 {
+					slog.Info("trash retention purged", "lists", result.Lists, "items", result.Items)
+				}
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
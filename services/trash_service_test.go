@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"testing"
+	"time"
+)
+
+func TestRestoreListKeepsSeparatelyDeletedItemsInTrash(t *testing.T) {
+	list := newTestList(t, testUser)
+	kept := newTestItem(t, testUser, list.ID, "Liste ile silinecek")
+	trashed := newTestItem(t, testUser, list.ID, "Önceden silinecek")
+
+	if err := DeleteItem(context.Background(), trashed.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteTodoList(context.Background(), list.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RestoreItem(context.Background(), kept.ID, testUser); err == nil || err.Error() != "list is deleted" {
+		t.Fatalf("restoring item of deleted list: err = %v", err)
+	}
+
+	restored, err := RestoreTodoList(context.Background(), list.ID, testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.DeletedAt != nil {
+		t.Fatal("list still deleted")
+	}
+	if _, err := repositories.GetItemByID(kept.ID); err != nil {
+		t.Errorf("item deleted with the list was not restored: %v", err)
+	}
+	if _, err := repositories.GetDeletedItemByID(trashed.ID); err != nil {
+		t.Errorf("item deleted before the list left the trash: %v", err)
+	}
+	if _, err := RestoreTodoList(context.Background(), list.ID, testUser); err == nil || err.Error() != "list not found in trash" {
+		t.Fatalf("second restore: err = %v", err)
+	}
+}
+
+func TestRestoreRequiresOwnership(t *testing.T) {
+	list := newTestList(t, testUser)
+	if err := DeleteTodoList(context.Background(), list.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+	other := models.Actor{UserID: 3, Username: "user2", Role: "user"}
+	if _, err := RestoreTodoList(context.Background(), list.ID, other); err == nil || err.Error() != "unauthorized" {
+		t.Fatalf("err = %v, want unauthorized", err)
+	}
+}
+
+func TestPurgeRedactsEventsAndAudit(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Gizli içerik")
+	if err := DeleteTodoList(context.Background(), list.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := PurgeItem(context.Background(), item.ID, testUser); err == nil || err.Error() != "forbidden" {
+		t.Fatalf("user purge: err = %v", err)
+	}
+	if err := PurgeTodoList(context.Background(), list.ID, testAdmin); err != nil {
+		t.Fatal(err)
+	}
+
+	events, _ := repositories.GetEventsSince(0)
+	for _, event := range events {
+		if event.ListID == list.ID && (event.Name != "" || event.Content != "") {
+			t.Errorf("event %d (%s) still has payload: %q %q", event.Seq, event.Type, event.Name, event.Content)
+		}
+	}
+	if _, err := repositories.GetTodoListByID(list.ID); err == nil {
+		t.Fatal("list still exists")
+	}
+
+	audits, _ := repositories.QueryAuditEvents(models.AuditFilter{Action: "list.purge", TargetID: list.ID})
+	if len(audits) != 1 {
+		t.Fatalf("got %d purge audit events", len(audits))
+	}
+	var before map[string]interface{}
+	if err := json.Unmarshal(audits[0].Before, &before); err != nil {
+		t.Fatal(err)
+	}
+	if _, hasName := before["name"]; hasName || before["id"] != float64(list.ID) {
+		t.Fatalf("purge audit before = %s", audits[0].Before)
+	}
+}
+
+func TestPurgeDeletedBeforeHonoursCutoff(t *testing.T) {
+	list := newTestList(t, testUser)
+	item := newTestItem(t, testUser, list.ID, "Çöp kutusunda")
+	if err := DeleteItem(context.Background(), item.ID, testUser); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := purgeDeletedBefore(context.Background(), systemActor, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repositories.GetDeletedItemByID(item.ID); err != nil {
+		t.Fatal("recently deleted item was purged")
+	}
+
+	result, err := purgeDeletedBefore(context.Background(), systemActor, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Items == 0 {
+		t.Fatal("nothing purged")
+	}
+	if _, err := repositories.GetDeletedItemByID(item.ID); err == nil {
+		t.Fatal("old deleted item was not purged")
+	}
+}
@@ -0,0 +1,373 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"priviatodolist/utils"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Kullanıcı adı ve şifreyi doğrular; başarısız denemeler denetim kaydına işlenir.
+// actor yalnızca IP ve istek kimliği için kullanılır. Kullanıcı adı lockout kuralına göre
+// kilitliyse şifre kontrol edilmeden "login locked" döner (bkz. LoginRetryAfter).
+func Authenticate(ctx context.Context, username, password string, actor models.Actor, lockout models.LoginLockout) (*models.User, error) {
+	ctx, span := startSpan(ctx, "Authenticate")
+	defer span.End()
+
+	actor.Username = username
+
+	// Kilit süresince yapılan denemeler sayılmaz; aksi halde kilit sürekli uzatılabilirdi
+	if LoginRetryAfter(username) > 0 {
+		recordLogin(LoginLocked)
+		RecordAudit(ctx, actor, "login.locked", AuditTargetUser, 0, nil, nil)
+		return nil, errors.New("login locked")
+	}
+
+	user, err := repositories.GetUserByUsername(username)
+	if err != nil {
+		recordLogin(LoginFailure)
+		recordLoginFailure(username, lockout)
+		RecordAudit(ctx, actor, "login.failed", AuditTargetUser, 0, nil, nil)
+		return nil, errors.New("invalid credentials")
+	}
+	actor.UserID = user.ID
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		recordLogin(LoginFailure)
+		recordLoginFailure(username, lockout)
+		RecordAudit(ctx, actor, "login.failed", AuditTargetUser, user.ID, nil, nil)
+		return nil, errors.New("invalid credentials")
+	}
+	if user.Disabled {
+		recordLogin(LoginFailure)
+		RecordAudit(ctx, actor, "login.failed", AuditTargetUser, user.ID, nil, nil)
+		return nil, errors.New("user disabled")
+	}
+	recordLogin(LoginSuccess)
+	repositories.ResetLoginAttempts(username)
+	return user, nil
+}
+
+// Kullanıcı adı kilitliyse kilidin açılmasına kalan süre, değilse 0
+func LoginRetryAfter(username string) time.Duration {
+	attempt := repositories.GetLoginAttempt(username)
+	if wait := time.Until(attempt.LockedUntil); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// Başarısız denemeyi kaydeder; eşik aşıldıysa kullanıcı adı üstel artan sürelerle kilitlenir.
+// Son hatadan MaxDelay kadar süre geçtiyse sayaç sıfırdan başlar.
+func recordLoginFailure(username string, lockout models.LoginLockout) {
+	repositories.RecordLoginFailure(username, time.Now(), lockout.MaxDelay, func(failures int) time.Duration {
+		if lockout.Threshold <= 0 || failures < lockout.Threshold {
+			return 0
+		}
+		delay := lockout.BaseDelay
+		for i := lockout.Threshold; i < failures && delay < lockout.MaxDelay; i++ {
+			delay *= 2
+		}
+		return min(delay, lockout.MaxDelay)
+	})
+}
+
+// Kullanıcı için yeni oturum açar
+func StartSession(ctx context.Context, user *models.User, actor models.Actor, userAgent string, ttl time.Duration) (*models.Session, error) {
+	ctx, span := startSpan(ctx, "StartSession")
+	defer span.End()
+
+	now := time.Now()
+	session, err := repositories.CreateSession(&models.Session{
+		ID:        utils.RandomToken(16),
+		UserID:    user.ID,
+		IP:        actor.IP,
+		UserAgent: userAgent,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	actor.UserID = user.ID
+	actor.Username = user.Username
+	RecordAudit(ctx, actor, "login", AuditTargetUser, user.ID, nil, nil)
+	return session, nil
+}
+
+// Oturum hâlâ geçerli mi ve kullanıcı (ve varsa impersonation yapan admin) aktif mi?
+func ValidateSession(sessionID string, userID, impersonatorID int) error {
+	session, err := repositories.GetSessionByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID || session.ImpersonatorID != impersonatorID ||
+		session.RevokedAt != nil || session.ExpiresAt.Before(time.Now()) {
+		return errors.New("session expired")
+	}
+	user, err := repositories.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.Disabled {
+		return errors.New("user disabled")
+	}
+	if impersonatorID != 0 {
+		admin, err := repositories.GetUserByID(impersonatorID)
+		if err != nil || admin.Disabled || !policy.HasPermission(admin.Role, policy.UsersImpersonate) {
+			return errors.New("impersonator not allowed")
+		}
+	}
+	return nil
+}
+
+// Admin için: hedef kullanıcı adına ttl süreli bir oturum açar.
+// Impersonation oturumundan yeni bir impersonation başlatılamaz. Kullanıcıları yönetebilen
+// veya impersonation yapabilen hesaplar hedef alınamaz; aksi halde admin başka bir admin'in kimliğini ve yetkilerini devralırdı.
+func Impersonate(ctx context.Context, userID int, actor models.Actor, ttl time.Duration) (*models.User, *models.Session, error) {
+	ctx, span := startSpan(ctx, "Impersonate")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersImpersonate) || actor.ImpersonatorID != 0 {
+		return nil, nil, errors.New("forbidden")
+	}
+	if userID == actor.UserID {
+		return nil, nil, errors.New("cannot impersonate yourself")
+	}
+	user, err := repositories.GetUserByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if policy.HasPermission(user.Role, policy.UsersImpersonate) || policy.HasPermission(user.Role, policy.UsersManage) {
+		return nil, nil, errors.New("forbidden")
+	}
+	if user.Disabled {
+		return nil, nil, errors.New("user disabled")
+	}
+
+	now := time.Now()
+	session, err := repositories.CreateSession(&models.Session{
+		ID:             utils.RandomToken(16),
+		UserID:         user.ID,
+		IP:             actor.IP,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(ttl),
+		ImpersonatorID: actor.UserID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	RecordAudit(ctx, actor, "impersonation.start", AuditTargetUser, user.ID, nil, map[string]interface{}{"expires_at": session.ExpiresAt})
+	return user, session, nil
+}
+
+// Admin için: tüm kullanıcıları getir
+func GetAllUsers(ctx context.Context, actor models.Actor) ([]*models.User, error) {
+	ctx, span := startSpan(ctx, "GetAllUsers")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) {
+		return nil, errors.New("forbidden")
+	}
+	return repositories.GetAllUsers()
+}
+
+// Admin için: tek kullanıcıyı getir
+func GetUser(ctx context.Context, userID int, actor models.Actor) (*models.User, error) {
+	ctx, span := startSpan(ctx, "GetUser")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) {
+		return nil, errors.New("forbidden")
+	}
+	return repositories.GetUserByID(userID)
+}
+
+// Admin için: yeni kullanıcı oluştur
+func CreateUser(ctx context.Context, actor models.Actor, req *models.UserCreate) (*models.User, error) {
+	ctx, span := startSpan(ctx, "CreateUser")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) {
+		return nil, errors.New("forbidden")
+	}
+	if len(req.Username) < 3 {
+		return nil, errors.New("username must be at least 3 characters")
+	}
+	if len(req.Password) < 4 {
+		return nil, errors.New("password must be at least 4 characters")
+	}
+	if !policy.RoleExists(req.Role) {
+		return nil, errors.New("unknown role")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user, err := repositories.CreateUser(&models.User{
+		Username: req.Username,
+		Password: string(hash),
+		Role:     req.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	RecordAudit(ctx, actor, "user.create", AuditTargetUser, user.ID, nil, userSnapshot(user))
+	return user, nil
+}
+
+// Admin için: kullanıcıyı devre dışı bırak veya yeniden etkinleştir.
+// Devre dışı bırakılan kullanıcının tüm oturumları iptal edilir.
+func SetUserDisabled(ctx context.Context, userID int, actor models.Actor, disabled bool) (*models.User, error) {
+	ctx, span := startSpan(ctx, "SetUserDisabled")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) {
+		return nil, errors.New("forbidden")
+	}
+	if disabled && userID == actor.UserID {
+		return nil, errors.New("cannot disable yourself")
+	}
+	user, err := repositories.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Son admin kontrolü güncellemeyle aynı kilit altında yapılır
+	before := userSnapshot(user)
+	user.Disabled = disabled
+	user, err = repositories.UpdateUserChecked(userID, user, keepActiveAdmin(errors.New("cannot disable the last admin")))
+	if err != nil {
+		return nil, err
+	}
+	if disabled {
+		if err := revokeUserSessions(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	action := "user.enable"
+	if disabled {
+		action = "user.disable"
+	}
+	RecordAudit(ctx, actor, action, AuditTargetUser, userID, before, userSnapshot(user))
+	return user, nil
+}
+
+// Admin için: kullanıcının rolünü değiştir
+func ChangeUserRole(ctx context.Context, userID int, actor models.Actor, role string) (*models.User, error) {
+	ctx, span := startSpan(ctx, "ChangeUserRole")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) {
+		return nil, errors.New("forbidden")
+	}
+	if !policy.RoleExists(role) {
+		return nil, errors.New("unknown role")
+	}
+	if userID == actor.UserID {
+		return nil, errors.New("cannot change your own role")
+	}
+	user, err := repositories.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := userSnapshot(user)
+	user.Role = role
+	user, err = repositories.UpdateUserChecked(userID, user, keepActiveAdmin(errors.New("cannot change the role of the last admin")))
+	if err != nil {
+		return nil, err
+	}
+	// Eski rolle verilmiş tokenlar geçersiz olsun
+	if err := revokeUserSessions(userID); err != nil {
+		return nil, err
+	}
+
+	RecordAudit(ctx, actor, "user.role_change", AuditTargetUser, userID, before, userSnapshot(user))
+	return user, nil
+}
+
+// Kullanıcının oturumlarını iptal eder ve açık stream bağlantılarını kapatır
+func revokeUserSessions(userID int) error {
+	if err := repositories.RevokeUserSessions(userID); err != nil {
+		return err
+	}
+	closeUserStreams(userID)
+	return nil
+}
+
+// Admin için: kullanıcının şifresini sıfırla ve oturumlarını kapat
+func ResetPassword(ctx context.Context, userID int, actor models.Actor, password string) error {
+	ctx, span := startSpan(ctx, "ResetPassword")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) {
+		return errors.New("forbidden")
+	}
+	if len(password) < 4 {
+		return errors.New("password must be at least 4 characters")
+	}
+	user, err := repositories.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.Password = string(hash)
+	if err := revokeUserSessions(userID); err != nil {
+		return err
+	}
+	if _, err = repositories.UpdateUser(userID, user); err != nil {
+		return err
+	}
+
+	RecordAudit(ctx, actor, "user.password_reset", AuditTargetUser, userID, nil, nil)
+	return nil
+}
+
+// Admin için: kullanıcının silinmişler dahil listelerini getir
+func GetUserTodoLists(ctx context.Context, userID int, actor models.Actor) ([]*models.TodoList, error) {
+	ctx, span := startSpan(ctx, "GetUserTodoLists")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) || !policy.HasPermission(actor.Role, policy.ListsReadAny) {
+		return nil, errors.New("forbidden")
+	}
+	if _, err := repositories.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+	lists, err := getTodoListsByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range lists {
+		CalculateListCompletion(list)
+	}
+	return lists, nil
+}
+
+// Admin için: kullanıcının aktif oturumlarını getir
+func GetUserSessions(ctx context.Context, userID int, actor models.Actor) ([]*models.Session, error) {
+	ctx, span := startSpan(ctx, "GetUserSessions")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.UsersManage) {
+		return nil, errors.New("forbidden")
+	}
+	if _, err := repositories.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+	return repositories.GetActiveSessionsByUserID(userID)
+}
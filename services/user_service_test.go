@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"priviatodolist/mockdb"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Test için geçici admin oluşturur; test sonunda silinir
+func newTestAdmin(t *testing.T, username string) models.Actor {
+	t.Helper()
+	user, err := CreateUser(context.Background(), testAdmin, &models.UserCreate{Username: username, Password: "secret", Role: "admin"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	t.Cleanup(func() { _ = repositories.DeleteUser(user.ID) })
+	return models.Actor{UserID: user.ID, Username: user.Username, Role: user.Role}
+}
+
+func TestChangeUserRoleRejectsSelfAndLastAdmin(t *testing.T) {
+	if _, err := ChangeUserRole(context.Background(), testAdmin.UserID, testAdmin, "user"); err == nil || err.Error() != "cannot change your own role" {
+		t.Fatalf("self demote: err = %v", err)
+	}
+
+	// Silinmiş bir admin'in hâlâ geçerli token'ı gibi: kullanıcıları yönetebilen başka aktif hesap yok
+	stale := models.Actor{UserID: 999, Username: "stale", Role: "admin"}
+	if _, err := ChangeUserRole(context.Background(), testAdmin.UserID, stale, "user"); err == nil || err.Error() != "cannot change the role of the last admin" {
+		t.Fatalf("last admin demote: err = %v", err)
+	}
+	if _, err := SetUserDisabled(context.Background(), testAdmin.UserID, stale, true); err == nil || err.Error() != "cannot disable the last admin" {
+		t.Fatalf("last admin disable: err = %v", err)
+	}
+	user, _ := repositories.GetUserByID(testAdmin.UserID)
+	if user.Role != "admin" || user.Disabled {
+		t.Fatalf("last admin changed: %+v", user)
+	}
+}
+
+func TestChangeUserRoleAllowedWhileAnotherAdminRemains(t *testing.T) {
+	other := newTestAdmin(t, "admin-role-test")
+
+	if _, err := ChangeUserRole(context.Background(), other.UserID, testAdmin, "user"); err != nil {
+		t.Fatalf("demote with another admin left: %v", err)
+	}
+	if _, err := ChangeUserRole(context.Background(), other.UserID, testAdmin, "admin"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// İki admin birbirini aynı anda düşürmeye çalışırsa en az biri admin kalmalı
+func TestConcurrentDemotionsKeepAnAdmin(t *testing.T) {
+	other := newTestAdmin(t, "admin-race-test")
+	t.Cleanup(func() { _, _ = ChangeUserRole(context.Background(), testAdmin.UserID, other, "admin") })
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); _, _ = ChangeUserRole(context.Background(), other.UserID, testAdmin, "user") }()
+	go func() { defer wg.Done(); _, _ = ChangeUserRole(context.Background(), testAdmin.UserID, other, "user") }()
+	wg.Wait()
+
+	users, _ := repositories.GetAllUsers()
+	if !hasActiveAdmin(users) {
+		t.Fatal("both admins were demoted")
+	}
+}
+
+// Kilidi süresi dolmuş gibi açar; sayaç korunur
+func expireLoginLock(username string) {
+	mockdb.LoginAttempts[username].LockedUntil = time.Time{}
+}
+
+func TestLoginLockoutBacksOffExponentially(t *testing.T) {
+	const username = "lockout-user"
+	newTestUser(t, username, "correct")
+	t.Cleanup(func() { repositories.ResetLoginAttempts(username) })
+	lockout := models.LoginLockout{Threshold: 2, BaseDelay: time.Minute, MaxDelay: 4 * time.Minute}
+	actor := models.Actor{IP: "192.0.2.10"}
+
+	if _, err := Authenticate(context.Background(), username, "wrong", actor, lockout); err == nil || err.Error() != "invalid credentials" {
+		t.Fatalf("first failure: err = %v", err)
+	}
+	if wait := LoginRetryAfter(username); wait != 0 {
+		t.Fatalf("locked below threshold for %v", wait)
+	}
+
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute} {
+		Authenticate(context.Background(), username, "wrong", actor, lockout)
+		if wait := LoginRetryAfter(username); wait <= want-time.Second || wait > want {
+			t.Fatalf("retry after = %v, want %v", wait, want)
+		}
+
+		// Kilit sırasında doğru parola da reddedilir ve sayaç artmaz
+		failures := repositories.GetLoginAttempt(username).Failures
+		if _, err := Authenticate(context.Background(), username, "correct", actor, lockout); err == nil || err.Error() != "login locked" {
+			t.Fatalf("login while locked: err = %v", err)
+		}
+		if got := repositories.GetLoginAttempt(username).Failures; got != failures {
+			t.Fatalf("failures changed while locked: %d -> %d", failures, got)
+		}
+		expireLoginLock(username)
+	}
+
+	if _, err := Authenticate(context.Background(), username, "correct", actor, lockout); err != nil {
+		t.Fatalf("login after lock expired: %v", err)
+	}
+	if got := repositories.GetLoginAttempt(username); got.Failures != 0 {
+		t.Fatalf("success did not reset the counter: %+v", got)
+	}
+}
+
+func TestLoginLockoutDisabled(t *testing.T) {
+	const username = "no-lockout-user"
+	t.Cleanup(func() { repositories.ResetLoginAttempts(username) })
+	lockout := models.LoginLockout{Threshold: 0, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	for range 10 {
+		if _, err := Authenticate(context.Background(), username, "wrong", models.Actor{}, lockout); err == nil || err.Error() != "invalid credentials" {
+			t.Fatalf("err = %v", err)
+		}
+	}
+	if wait := LoginRetryAfter(username); wait != 0 {
+		t.Fatalf("locked for %v with lockout disabled", wait)
+	}
+}
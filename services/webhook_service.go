@@ -0,0 +1,391 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"priviatodolist/models"
+	"priviatodolist/policy"
+	"priviatodolist/repositories"
+	"priviatodolist/utils"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Teslimat ayarları
+var (
+	WebhookMaxAttempts             = 5
+	WebhookInitialBackoff          = 2 * time.Second
+	WebhookMaxConcurrentDeliveries = 16 // Aynı anda yapılan en fazla teslimat
+	webhookClient                  = newWebhookClient()
+)
+
+// Webhook hedefine izin verilen adresler; testler yerel alıcı için değiştirebilir
+var webhookAddrAllowed = publicWebhookAddr
+
+var errWebhookPrivateAddr = errors.New("url must not point to a loopback, link-local or private address")
+
+// Carrier-grade NAT aralığı (RFC 6598) net.IP.IsPrivate kapsamında değildir
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// SSRF'e karşı yalnızca genel internet adreslerine izin verir
+func publicWebhookAddr(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedAddressSpace.Contains(ip))
+}
+
+// Yönlendirmeleri izlemeyen ve bağlantı anında çözülen adresi tekrar kontrol eden istemci.
+// Oluşturma anındaki kontrol DNS değişikliğiyle (DNS rebinding) atlatılamaz.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !webhookAddrAllowed(ip) {
+				return errWebhookPrivateAddr
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			// Proxy üzerinden gidilirse hedef adres kontrol edilemez
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// 3xx yanıtı başarısız teslimat olarak kaydedilir
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// URL'nin mutlak http(s) adresi olduğunu ve host'un yalnızca izin verilen adreslere çözüldüğünü kontrol eder
+func validateWebhookURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("url must be an absolute http(s) URL")
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !webhookAddrAllowed(ip) {
+			return errWebhookPrivateAddr
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return errors.New("url host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if !webhookAddrAllowed(addr.IP) {
+			return errWebhookPrivateAddr
+		}
+	}
+	return nil
+}
+
+// Webhook isteğinde gönderilen gövde; teslimat ID'si X-Webhook-Delivery başlığındadır
+type webhookPayload struct {
+	Event     string             `json:"event"`
+	CreatedAt time.Time          `json:"created_at"`
+	Data      models.DomainEvent `json:"data"`
+}
+
+func validWebhookEvent(name string) bool {
+	if name == "*" {
+		return true
+	}
+	for _, public := range models.PublicEventNames {
+		if public == name {
+			return true
+		}
+	}
+	return false
+}
+
+func webhookSubscribed(hook *models.Webhook, event string) bool {
+	for _, e := range hook.Events {
+		if e == "*" || e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Kullanıcı için yeni webhook kaydet; imza anahtarı yalnızca bu yanıtta döner.
+// URL'nin DNS çözümlemesi ctx iptal edildiğinde (ör. istemci bağlantıyı kapattığında) yarıda kesilir.
+func CreateWebhook(ctx context.Context, actor models.Actor, req *models.WebhookCreate) (*models.Webhook, error) {
+	ctx, span := startSpan(ctx, "CreateWebhook")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.WebhooksManageOwn) {
+		return nil, errors.New("forbidden")
+	}
+	if err := validateWebhookURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+	events := req.Events
+	if len(events) == 0 {
+		events = []string{"*"}
+	}
+	for _, e := range events {
+		if !validWebhookEvent(e) {
+			return nil, fmt.Errorf("unknown event %q", e)
+		}
+	}
+
+	return repositories.CreateWebhook(&models.Webhook{
+		UserID: actor.UserID,
+		URL:    req.URL,
+		Events: events,
+		Secret: utils.RandomToken(32),
+	})
+}
+
+// Kullanıcının webhook'larını imza anahtarları olmadan getir
+func GetMyWebhooks(ctx context.Context, actor models.Actor) ([]*models.Webhook, error) {
+	ctx, span := startSpan(ctx, "GetMyWebhooks")
+	defer span.End()
+
+	if !policy.HasPermission(actor.Role, policy.WebhooksManageOwn) {
+		return nil, errors.New("forbidden")
+	}
+	hooks, err := repositories.GetWebhooksByUserID(actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range hooks {
+		hook.Secret = ""
+	}
+	return hooks, nil
+}
+
+func getOwnWebhook(ctx context.Context, webhookID int, actor models.Actor) (*models.Webhook, error) {
+	hook, err := repositories.GetWebhookByID(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CanAccess(actor.Role, actor.UserID, hook.UserID, policy.ResourceWebhooks, policy.ActionManage) {
+		return nil, errors.New("webhook not found")
+	}
+	return hook, nil
+}
+
+func DeleteWebhook(ctx context.Context, webhookID int, actor models.Actor) error {
+	ctx, span := startSpan(ctx, "DeleteWebhook")
+	defer span.End()
+
+	if _, err := getOwnWebhook(ctx, webhookID, actor); err != nil {
+		return err
+	}
+	return repositories.DeleteWebhook(webhookID)
+}
+
+func GetWebhookDeliveries(ctx context.Context, webhookID int, actor models.Actor) ([]*models.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "GetWebhookDeliveries")
+	defer span.End()
+
+	if _, err := getOwnWebhook(ctx, webhookID, actor); err != nil {
+		return nil, err
+	}
+	return repositories.GetWebhookDeliveries(webhookID)
+}
+
+// Webhook'a "webhook.test" event'ini tek denemeyle hemen gönderir ve teslimat kaydını döner
+func TestWebhook(ctx context.Context, webhookID int, actor models.Actor) (*models.WebhookDelivery, error) {
+	ctx, span := startSpan(ctx, "TestWebhook")
+	defer span.End()
+
+	hook, err := getOwnWebhook(ctx, webhookID, actor)
+	if err != nil {
+		return nil, err
+	}
+	delivery, body, err := newWebhookDelivery(hook, "webhook.test", models.DomainEvent{Type: "WebhookTest", ActorID: actor.UserID, OwnerID: hook.UserID, Time: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	statusCode, err := sendWebhook(ctx, hook, delivery, body)
+	return finishWebhookAttempt(delivery.ID, 1, 1, statusCode, err)
+}
+
+func newWebhookDelivery(hook *models.Webhook, event string, data models.DomainEvent) (*models.WebhookDelivery, []byte, error) {
+	body, err := json.Marshal(webhookPayload{
+		Event:     event,
+		CreatedAt: time.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	delivery, err := repositories.CreateWebhookDelivery(&models.WebhookDelivery{
+		WebhookID: hook.ID,
+		Event:     event,
+		Payload:   body,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, body, nil
+}
+
+// SignWebhookPayload, "<timestamp>.<body>" içeriğinin HMAC-SHA256 imzasını hex olarak döner.
+// Alıcılar X-Webhook-Timestamp ve gövde ile aynı imzayı hesaplayıp X-Webhook-Signature ile karşılaştırmalıdır.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func sendWebhook(ctx context.Context, hook *models.Webhook, delivery *models.WebhookDelivery, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PriviaTodoList-Webhook/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.Event)
+	req.Header.Set("X-Webhook-Delivery", strconv.Itoa(delivery.ID))
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(hook.Secret, timestamp, body))
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func finishWebhookAttempt(deliveryID, attempt, maxAttempts, statusCode int, err error) (*models.WebhookDelivery, error) {
+	status, errMsg := models.DeliverySucceeded, ""
+	if err != nil {
+		errMsg = err.Error()
+		status = models.DeliveryPending
+		if attempt >= maxAttempts {
+			status = models.DeliveryFailed
+		}
+	}
+	return repositories.UpdateWebhookDelivery(deliveryID, status, attempt, statusCode, errMsg)
+}
+
+// Teslimatı başarılı olana veya deneme hakkı bitene kadar üstel bekleme ile tekrarlar
+func deliverWebhook(ctx context.Context, hook *models.Webhook, delivery *models.WebhookDelivery, body []byte) {
+	backoff := WebhookInitialBackoff
+	for attempt := 1; attempt <= WebhookMaxAttempts; attempt++ {
+		statusCode, err := sendWebhook(ctx, hook, delivery, body)
+		if _, uerr := finishWebhookAttempt(delivery.ID, attempt, WebhookMaxAttempts, statusCode, err); uerr != nil {
+			slog.Error("updating webhook delivery failed", "delivery_id", delivery.ID, "error", uerr)
+			return
+		}
+		if err == nil || attempt == WebhookMaxAttempts {
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			repositories.UpdateWebhookDelivery(delivery.ID, models.DeliveryFailed, attempt, statusCode, "delivery canceled: "+ctx.Err().Error())
+			return
+		}
+	}
+}
+
+// StartWebhookDispatcher, event log'a eklenen event'leri ilgili webhook'lara teslim eder.
+// Dönen fonksiyon yeni teslimatları durdurur, bekleyen tekrarları iptal eder ve işlemlerin bitmesini bekler.
+func StartWebhookDispatcher() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := make(chan models.DomainEvent, 1000)
+	slots := make(chan struct{}, WebhookMaxConcurrentDeliveries)
+	var wg sync.WaitGroup
+
+	repositories.SubscribeEvents(func(event models.DomainEvent) {
+		select {
+		case queue <- event:
+		case <-ctx.Done():
+		default:
+			slog.Warn("webhook queue full, dropping event", "seq", event.Seq)
+		}
+	})
+
+	setWorkerRunning(WorkerWebhookDispatch, true)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer setWorkerRunning(WorkerWebhookDispatch, false)
+		for {
+			select {
+			case event := <-queue:
+				dispatchWebhookEvent(ctx, &wg, slots, event)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+// Her teslimat slots'tan bir yer alır; yer yoksa dispatcher bekler ve yeni event'ler kuyrukta birikir
+func dispatchWebhookEvent(ctx context.Context, wg *sync.WaitGroup, slots chan struct{}, event models.DomainEvent) {
+	name := models.PublicEventNames[event.Type]
+	hooks, err := repositories.GetWebhooksByUserID(event.OwnerID)
+	if err != nil {
+		slog.Error("loading webhooks failed", "user_id", event.OwnerID, "error", err)
+		return
+	}
+	for _, hook := range hooks {
+		if !webhookSubscribed(hook, name) {
+			continue
+		}
+		delivery, body, err := newWebhookDelivery(hook, name, event)
+		if err != nil {
+			slog.Error("creating webhook delivery failed", "webhook_id", hook.ID, "error", err)
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			repositories.UpdateWebhookDelivery(delivery.ID, models.DeliveryFailed, 0, 0, "delivery canceled: "+ctx.Err().Error())
+			continue
+		}
+		wg.Add(1)
+		go func(hook *models.Webhook) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			deliverWebhook(ctx, hook, delivery, body)
+		}(hook)
+	}
+}
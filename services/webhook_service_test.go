@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"priviatodolist/models"
+	"priviatodolist/repositories"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Testlerdeki httptest alıcısı loopback'te çalıştığı için adres kontrolünü geçici olarak kapatır
+func allowLocalWebhooks(t *testing.T) {
+	t.Helper()
+	webhookAddrAllowed = func(net.IP) bool { return true }
+	t.Cleanup(func() { webhookAddrAllowed = publicWebhookAddr })
+}
+
+func newTestWebhook(t *testing.T, url string) *models.Webhook {
+	t.Helper()
+	hook, err := repositories.CreateWebhook(&models.Webhook{UserID: testUser.UserID, URL: url, Events: []string{"*"}, Secret: "test-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repositories.DeleteWebhook(hook.ID) })
+	return hook
+}
+
+func TestCreateWebhookRejectsPrivateTargets(t *testing.T) {
+	for _, url := range []string{
+		"http://localhost/hook",
+		"http://127.0.0.1:8080/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.0.0.5/hook",
+		"https://172.16.1.1/hook",
+		"http://192.168.1.10/hook",
+		"http://100.64.0.1/hook",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://0.0.0.0/hook",
+	} {
+		_, err := CreateWebhook(context.Background(), testUser, &models.WebhookCreate{URL: url})
+		if err != errWebhookPrivateAddr {
+			t.Errorf("%s: err = %v", url, err)
+		}
+	}
+
+	if _, err := CreateWebhook(context.Background(), testUser, &models.WebhookCreate{URL: "ftp://example.com/hook"}); err == nil {
+		t.Error("non-http URL was accepted")
+	}
+	hook, err := CreateWebhook(context.Background(), testUser, &models.WebhookCreate{URL: "https://93.184.216.34/hook"})
+	if err != nil {
+		t.Fatalf("public address rejected: %v", err)
+	}
+	repositories.DeleteWebhook(hook.ID)
+}
+
+// İstek iptal edildiyse host çözümlemesi yarıda kesilir ve webhook kaydedilmez
+func TestCreateWebhookUsesRequestContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CreateWebhook(ctx, testUser, &models.WebhookCreate{URL: "https://example.com/hook"}); err == nil {
+		t.Fatal("webhook was created with a canceled context")
+	}
+	if hooks, _ := repositories.GetWebhooksByUserID(testUser.UserID); len(hooks) != 0 {
+		t.Fatalf("hooks = %+v", hooks)
+	}
+}
+
+func TestWebhookDialRejectsPrivateTargets(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer server.Close()
+
+	// Oluşturma kontrolünden sonra loopback'e çözülen bir hedef de bağlantı anında reddedilir
+	hook := newTestWebhook(t, server.URL)
+	delivery, err := TestWebhook(context.Background(), hook.ID, testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != models.DeliveryFailed || !strings.Contains(delivery.Error, errWebhookPrivateAddr.Error()) {
+		t.Fatalf("delivery = %+v", delivery)
+	}
+	if atomic.LoadInt32(&hits) != 0 {
+		t.Fatal("request reached a loopback receiver")
+	}
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	allowLocalWebhooks(t)
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+	got := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- received{r.Header.Clone(), body}
+	}))
+	defer server.Close()
+
+	hook := newTestWebhook(t, server.URL)
+	delivery, err := TestWebhook(context.Background(), hook.ID, testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != models.DeliverySucceeded || delivery.Attempts != 1 || delivery.StatusCode != http.StatusOK {
+		t.Fatalf("delivery = %+v", delivery)
+	}
+
+	r := <-got
+	want := "sha256=" + SignWebhookPayload(hook.Secret, r.header.Get("X-Webhook-Timestamp"), r.body)
+	if r.header.Get("X-Webhook-Signature") != want {
+		t.Fatalf("signature = %q, want %q", r.header.Get("X-Webhook-Signature"), want)
+	}
+	if r.header.Get("X-Webhook-Event") != "webhook.test" || r.header.Get("X-Webhook-Delivery") == "" {
+		t.Fatalf("headers = %v", r.header)
+	}
+	var payload webhookPayload
+	if err := json.Unmarshal(r.body, &payload); err != nil || payload.Event != "webhook.test" {
+		t.Fatalf("payload = %s (%v)", r.body, err)
+	}
+}
+
+func TestWebhookDeliveryRetries(t *testing.T) {
+	allowLocalWebhooks(t)
+	oldBackoff := WebhookInitialBackoff
+	WebhookInitialBackoff = time.Millisecond
+	defer func() { WebhookInitialBackoff = oldBackoff }()
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	hook := newTestWebhook(t, server.URL)
+	delivery, body, err := newWebhookDelivery(hook, "item.created", models.DomainEvent{Type: models.EventItemAdded})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deliverWebhook(context.Background(), hook, delivery, body)
+
+	deliveries, _ := repositories.GetWebhookDeliveries(hook.ID)
+	if len(deliveries) != 1 || deliveries[0].Status != models.DeliverySucceeded || deliveries[0].Attempts != 3 {
+		t.Fatalf("deliveries = %+v", deliveries)
+	}
+
+	// Alıcı hep hata dönerse deneme hakkı bitince teslimat başarısız sayılır
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	hook = newTestWebhook(t, failing.URL)
+	delivery, body, _ = newWebhookDelivery(hook, "item.created", models.DomainEvent{Type: models.EventItemAdded})
+	deliverWebhook(context.Background(), hook, delivery, body)
+
+	deliveries, _ = repositories.GetWebhookDeliveries(hook.ID)
+	if deliveries[0].Status != models.DeliveryFailed || deliveries[0].Attempts != WebhookMaxAttempts || deliveries[0].StatusCode != http.StatusInternalServerError {
+		t.Fatalf("deliveries = %+v", deliveries)
+	}
+}
+
+func TestWebhookRedirectsAreNotFollowed(t *testing.T) {
+	allowLocalWebhooks(t)
+	var followed int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/internal" {
+			atomic.AddInt32(&followed, 1)
+			return
+		}
+		http.Redirect(w, r, "/internal", http.StatusFound)
+	}))
+	defer server.Close()
+
+	hook := newTestWebhook(t, server.URL+"/hook")
+	delivery, err := TestWebhook(context.Background(), hook.ID, testUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != models.DeliveryFailed || delivery.StatusCode != http.StatusFound {
+		t.Fatalf("delivery = %+v", delivery)
+	}
+	if atomic.LoadInt32(&followed) != 0 {
+		t.Fatal("redirect was followed")
+	}
+}
+
+// Kapanışta bekleyen tekrarlar iptal edilir ve dispatcher hemen durur
+func TestWebhookDispatcherStopCancelsRetries(t *testing.T) {
+	allowLocalWebhooks(t)
+	oldBackoff := WebhookInitialBackoff
+	WebhookInitialBackoff = time.Hour
+	defer func() { WebhookInitialBackoff = oldBackoff }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	hook := newTestWebhook(t, server.URL)
+
+	stop := StartWebhookDispatcher()
+	newTestList(t, testUser)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		deliveries, _ := repositories.GetWebhookDeliveries(hook.ID)
+		if len(deliveries) > 0 && deliveries[0].Attempts == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("first delivery attempt was not made")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("dispatcher did not stop while a retry was pending")
+	}
+
+	deliveries, _ := repositories.GetWebhookDeliveries(hook.ID)
+	if deliveries[0].Status != models.DeliveryFailed || !strings.HasPrefix(deliveries[0].Error, "delivery canceled") {
+		t.Fatalf("delivery = %+v", deliveries[0])
+	}
+}
+
+// Aynı anda yapılan teslimat sayısı WebhookMaxConcurrentDeliveries'i aşmaz; kalanlar sırayla gönderilir
+func TestWebhookDeliveriesAreBounded(t *testing.T) {
+	allowLocalWebhooks(t)
+	oldMax := WebhookMaxConcurrentDeliveries
+	WebhookMaxConcurrentDeliveries = 2
+	defer func() { WebhookMaxConcurrentDeliveries = oldMax }()
+
+	var inFlight, maxInFlight, delivered int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&inFlight, -1)
+		atomic.AddInt32(&delivered, 1)
+	}))
+	defer server.Close()
+	newTestWebhook(t, server.URL)
+
+	stop := StartWebhookDispatcher()
+	defer stop()
+	for i := 0; i < 5; i++ {
+		newTestList(t, testUser)
+	}
+
+	// İki teslimat alıcıda beklerken diğerleri başlamamalı
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&inFlight) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("deliveries were not started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if n := atomic.LoadInt32(&inFlight); n != 2 {
+		t.Fatalf("%d deliveries in flight, want 2", n)
+	}
+
+	close(release)
+	for atomic.LoadInt32(&delivered) < 5 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d of 5 deliveries made", atomic.LoadInt32(&delivered))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if m := atomic.LoadInt32(&maxInFlight); m != 2 {
+		t.Fatalf("max in-flight deliveries = %d, want 2", m)
+	}
+}
@@ -0,0 +1,230 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Desteklenen exporter'lar
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Toplu gönderim ayarları
+const (
+	queueSize     = 2048
+	batchSize     = 256
+	flushInterval = 2 * time.Second
+)
+
+type exportFunc func(spans []spanData) error
+
+var (
+	exportMu sync.RWMutex
+	queue    chan *Span
+)
+
+// Setup bitmiş span'ları exporter'a toplu olarak gönderen arka plan işini başlatır.
+// Dönen fonksiyon kuyruktaki span'ları gönderip işi durdurur.
+func Setup(exporter, endpoint, serviceName string) (stop func()) {
+	var export exportFunc
+	switch exporter {
+	case ExporterStdout:
+		export = stdoutExporter(os.Stdout)
+	case ExporterOTLP:
+		export = otlpExporter(endpoint, serviceName)
+	default:
+		return func() {}
+	}
+
+	spans := make(chan *Span, queueSize)
+	exportMu.Lock()
+	queue = spans
+	exportMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(flushInterval)
+		defer ticker.Stop()
+
+		var batch []spanData
+		flush := func() {
+			if len(batch) == 0 {
+				return
+			}
+			if err := export(batch); err != nil {
+				slog.Warn("exporting spans failed", "spans", len(batch), "error", err)
+			}
+			batch = nil
+		}
+		for {
+			select {
+			case span, ok := <-spans:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, span.data())
+				if len(batch) >= batchSize {
+					flush()
+				}
+			case <-ticker.C:
+				flush()
+			}
+		}
+	}()
+
+	return func() {
+		exportMu.Lock()
+		queue = nil
+		close(spans)
+		exportMu.Unlock()
+		<-done
+	}
+}
+
+func enqueue(span *Span) {
+	exportMu.RLock()
+	defer exportMu.RUnlock()
+
+	if queue == nil {
+		return
+	}
+	select {
+	case queue <- span:
+	default:
+		// Kuyruk doluysa istek yavaşlatılmaz, span düşürülür
+	}
+}
+
+// Her span'ı ayrı bir JSON satırı olarak yazar
+func stdoutExporter(w io.Writer) exportFunc {
+	enc := json.NewEncoder(w)
+	return func(spans []spanData) error {
+		for _, s := range spans {
+			attrs := map[string]any{}
+			for _, a := range s.attrs {
+				attrs[a.Key] = a.Value
+			}
+			record := map[string]any{
+				"trace_id":    s.traceID,
+				"span_id":     s.spanID,
+				"name":        s.name,
+				"kind":        kindName(s.kind),
+				"start":       s.start,
+				"end":         s.end,
+				"duration_ms": float64(s.end.Sub(s.start).Microseconds()) / 1000,
+			}
+			if s.parentID != "" {
+				record["parent_span_id"] = s.parentID
+			}
+			if len(attrs) > 0 {
+				record["attributes"] = attrs
+			}
+			if s.statusCode == StatusError {
+				record["error"] = s.statusMessage
+			}
+			if err := enc.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func kindName(kind SpanKind) string {
+	if kind == KindServer {
+		return "server"
+	}
+	return "internal"
+}
+
+// OTLP/HTTP JSON kodlamasıyla endpoint'in /v1/traces yoluna gönderir
+func otlpExporter(endpoint, serviceName string) exportFunc {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasSuffix(url, "/v1/traces") {
+		url += "/v1/traces"
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resource := map[string]any{
+		"attributes": []map[string]any{otlpAttr("service.name", serviceName)},
+	}
+
+	return func(spans []spanData) error {
+		out := make([]map[string]any, 0, len(spans))
+		for _, s := range spans {
+			attrs := make([]map[string]any, 0, len(s.attrs))
+			for _, a := range s.attrs {
+				attrs = append(attrs, otlpAttr(a.Key, a.Value))
+			}
+			span := map[string]any{
+				"traceId":           s.traceID,
+				"spanId":            s.spanID,
+				"name":              s.name,
+				"kind":              int(s.kind),
+				"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+				"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+				"attributes":        attrs,
+				"status":            map[string]any{"code": s.statusCode, "message": s.statusMessage},
+			}
+			if s.parentID != "" {
+				span["parentSpanId"] = s.parentID
+			}
+			out = append(out, span)
+		}
+		body, err := json.Marshal(map[string]any{
+			"resourceSpans": []map[string]any{{
+				"resource": resource,
+				"scopeSpans": []map[string]any{{
+					"scope": map[string]any{"name": serviceName},
+					"spans": out,
+				}},
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		io.Copy(io.Discard, resp.Body)
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("collector responded with %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+func otlpAttr(key string, value any) map[string]any {
+	var v map[string]any
+	switch val := value.(type) {
+	case string:
+		v = map[string]any{"stringValue": val}
+	case bool:
+		v = map[string]any{"boolValue": val}
+	case int:
+		v = map[string]any{"intValue": strconv.Itoa(val)}
+	case int64:
+		v = map[string]any{"intValue": strconv.FormatInt(val, 10)}
+	case float64:
+		v = map[string]any{"doubleValue": val}
+	default:
+		v = map[string]any{"stringValue": fmt.Sprint(val)}
+	}
+	return map[string]any{"key": key, "value": v}
+}
@@ -0,0 +1,198 @@
+// Package tracing istekler, servis çağrıları ve depolama işlemleri için span üretir.
+// Span'lar W3C traceparent başlığıyla ilişkilendirilir ve stdout'a veya bir OTLP/HTTP
+// toplayıcısına (JSON) gönderilir. Exporter kurulmamışsa span'lar yalnızca ID taşır.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type SpanKind int
+
+// OTLP span türleri
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+)
+
+// OTLP durum kodları
+const (
+	StatusUnset = 0
+	StatusOK    = 1
+	StatusError = 2
+)
+
+type Attr struct {
+	Key   string
+	Value any
+}
+
+type Span struct {
+	TraceID  [16]byte
+	SpanID   [8]byte
+	ParentID [8]byte
+	Name     string
+	Kind     SpanKind
+	Start    time.Time
+
+	mu            sync.Mutex
+	end           time.Time
+	attrs         []Attr
+	statusCode    int
+	statusMessage string
+	ended         bool
+}
+
+type contextKey struct{}
+
+// Context'teki span'ın altında yeni bir span başlatır; span yoksa yeni bir trace açılır
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	span := &Span{Name: name, Kind: KindInternal, Start: time.Now(), SpanID: newSpanID()}
+	if parent := FromContext(ctx); parent != nil {
+		span.TraceID = parent.TraceID
+		span.ParentID = parent.SpanID
+	} else {
+		span.TraceID = newTraceID()
+	}
+	return context.WithValue(ctx, contextKey{}, span), span
+}
+
+// StartServer gelen isteğin span'ını başlatır. traceparent geçerliyse span çağıranın trace'ine eklenir.
+func StartServer(ctx context.Context, name, traceparent string) (context.Context, *Span) {
+	span := &Span{Name: name, Kind: KindServer, Start: time.Now(), SpanID: newSpanID()}
+	if traceID, parentID, ok := ParseTraceParent(traceparent); ok {
+		span.TraceID = traceID
+		span.ParentID = parentID
+	} else {
+		span.TraceID = newTraceID()
+	}
+	return context.WithValue(ctx, contextKey{}, span), span
+}
+
+// Context'teki span; yoksa nil
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(contextKey{}).(*Span)
+	return span
+}
+
+func (s *Span) SetAttr(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attrs = append(s.attrs, Attr{Key: key, Value: value})
+}
+
+// Span'ı hatalı olarak işaretler; nil hata yok sayılır
+func (s *Span) SetError(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statusCode = StatusError
+	s.statusMessage = err.Error()
+}
+
+// Span'ı bitirir ve exporter'a kuyruğa ekler; ikinci çağrı etkisizdir
+func (s *Span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+
+	enqueue(s)
+}
+
+func (s *Span) TraceIDString() string {
+	return hex.EncodeToString(s.TraceID[:])
+}
+
+func (s *Span) SpanIDString() string {
+	return hex.EncodeToString(s.SpanID[:])
+}
+
+// Span'ı başka bir servise iletmek için W3C traceparent değeri
+func (s *Span) TraceParent() string {
+	return fmt.Sprintf("00-%s-%s-01", s.TraceIDString(), s.SpanIDString())
+}
+
+// "00-<trace-id>-<parent-id>-<flags>" biçimindeki başlığı çözer; sıfır ID'ler geçersizdir
+func ParseTraceParent(value string) (traceID [16]byte, parentID [8]byte, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return traceID, parentID, false
+	}
+	if parts[0] == "00" && len(parts) != 4 {
+		return traceID, parentID, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(parts[1])); err != nil {
+		return traceID, parentID, false
+	}
+	if _, err := hex.Decode(parentID[:], []byte(parts[2])); err != nil {
+		return traceID, parentID, false
+	}
+	if traceID == [16]byte{} || parentID == [8]byte{} {
+		return traceID, parentID, false
+	}
+	return traceID, parentID, true
+}
+
+func newTraceID() (id [16]byte) {
+	for id == [16]byte{} {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() (id [8]byte) {
+	for id == [8]byte{} {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+// Bitmiş span'ın dışa aktarılabilir kopyası
+type spanData struct {
+	traceID, spanID, parentID string
+	name                      string
+	kind                      SpanKind
+	start, end                time.Time
+	attrs                     []Attr
+	statusCode                int
+	statusMessage             string
+}
+
+func (s *Span) data() spanData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d := spanData{
+		traceID:       s.TraceIDString(),
+		spanID:        s.SpanIDString(),
+		name:          s.Name,
+		kind:          s.Kind,
+		start:         s.Start,
+		end:           s.end,
+		attrs:         append([]Attr(nil), s.attrs...),
+		statusCode:    s.statusCode,
+		statusMessage: s.statusMessage,
+	}
+	if s.ParentID != [8]byte{} {
+		d.parentID = hex.EncodeToString(s.ParentID[:])
+	}
+	return d
+}
@@ -0,0 +1,186 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type collectedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	} `json:"attributes"`
+	Status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+// Yerel bir OTLP/HTTP toplayıcısı; gelen span'ları ve servis adını saklar
+type collector struct {
+	mu       sync.Mutex
+	paths    []string
+	services []string
+	spans    []collectedSpan
+}
+
+func newCollector(t *testing.T) (*collector, *httptest.Server) {
+	t.Helper()
+	c := &collector{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ResourceSpans []struct {
+				Resource struct {
+					Attributes []struct {
+						Key   string `json:"key"`
+						Value struct {
+							StringValue string `json:"stringValue"`
+						} `json:"value"`
+					} `json:"attributes"`
+				} `json:"resource"`
+				ScopeSpans []struct {
+					Spans []collectedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.paths = append(c.paths, r.URL.Path)
+		for _, rs := range body.ResourceSpans {
+			for _, a := range rs.Resource.Attributes {
+				if a.Key == "service.name" {
+					c.services = append(c.services, a.Value.StringValue)
+				}
+			}
+			for _, ss := range rs.ScopeSpans {
+				c.spans = append(c.spans, ss.Spans...)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return c, server
+}
+
+func (c *collector) byName(name string) (collectedSpan, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.spans {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return collectedSpan{}, false
+}
+
+func TestParseTraceParent(t *testing.T) {
+	valid := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	traceID, parentID, ok := ParseTraceParent(valid)
+	if !ok {
+		t.Fatalf("%q rejected", valid)
+	}
+	span := &Span{TraceID: traceID, SpanID: parentID}
+	if span.TraceParent() != valid {
+		t.Fatalf("round trip = %q, want %q", span.TraceParent(), valid)
+	}
+	if _, _, ok := ParseTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future"); !ok {
+		t.Error("future version with extra fields should be accepted")
+	}
+
+	for _, value := range []string{
+		"",
+		"garbage",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-zzf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	} {
+		if _, _, ok := ParseTraceParent(value); ok {
+			t.Errorf("%q accepted", value)
+		}
+	}
+}
+
+func TestChildSpansShareTrace(t *testing.T) {
+	ctx, server := StartServer(context.Background(), "GET /", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if server.TraceIDString() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("server trace = %s", server.TraceIDString())
+	}
+	_, child := Start(ctx, "child")
+	if child.TraceID != server.TraceID || child.ParentID != server.SpanID {
+		t.Fatal("child span is not linked to the server span")
+	}
+
+	_, root := StartServer(context.Background(), "GET /", "invalid")
+	if root.TraceID == server.TraceID || root.ParentID != [8]byte{} {
+		t.Fatal("invalid traceparent should start a new trace")
+	}
+}
+
+func TestOTLPExport(t *testing.T) {
+	c, server := newCollector(t)
+	stop := Setup(ExporterOTLP, server.URL, "todo-test")
+
+	ctx, parent := StartServer(context.Background(), "GET /items", "")
+	parent.SetAttr("http.response.status_code", 500)
+	parent.SetAttr("request_id", "req-1")
+	_, child := Start(ctx, "services.GetItems")
+	child.SetError(errors.New("boom"))
+	child.End()
+	child.End()
+	parent.End()
+	stop()
+
+	if len(c.paths) != 1 || c.paths[0] != "/v1/traces" {
+		t.Fatalf("collector paths = %v", c.paths)
+	}
+	if len(c.services) != 1 || c.services[0] != "todo-test" {
+		t.Fatalf("service.name = %v", c.services)
+	}
+	if len(c.spans) != 2 {
+		t.Fatalf("got %d spans, want 2 (End twice must export once)", len(c.spans))
+	}
+
+	gotParent, _ := c.byName("GET /items")
+	gotChild, _ := c.byName("services.GetItems")
+	if gotParent.Kind != int(KindServer) || gotChild.Kind != int(KindInternal) {
+		t.Errorf("kinds = %d, %d", gotParent.Kind, gotChild.Kind)
+	}
+	if gotChild.TraceID != gotParent.TraceID || gotChild.ParentSpanID != gotParent.SpanID {
+		t.Errorf("child %+v is not under parent %+v", gotChild, gotParent)
+	}
+	if gotParent.ParentSpanID != "" {
+		t.Errorf("root span has parent %q", gotParent.ParentSpanID)
+	}
+	if gotChild.Status.Code != StatusError || gotChild.Status.Message != "boom" {
+		t.Errorf("child status = %+v", gotChild.Status)
+	}
+	attrs := map[string]map[string]any{}
+	for _, a := range gotParent.Attributes {
+		attrs[a.Key] = a.Value
+	}
+	if attrs["http.response.status_code"]["intValue"] != "500" || attrs["request_id"]["stringValue"] != "req-1" {
+		t.Errorf("parent attributes = %v", attrs)
+	}
+
+	// Durdurulduktan sonra biten span'lar gönderilmez
+	_, late := Start(context.Background(), "late")
+	late.End()
+	if _, found := c.byName("late"); found {
+		t.Error("span exported after stop")
+	}
+}
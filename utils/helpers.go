@@ -1,18 +1,28 @@
 package utils
 
 import (
-	"log"
+	"log/slog"
+	"priviatodolist/logging"
 
 	"github.com/gin-gonic/gin"
 )
 
 func HandleError(c *gin.Context, statusCode int, err error, message string) {
 	errorResponse := ErrorResponse{
-		Status:  statusCode,
-		Message: message,
+		Status:    statusCode,
+		Message:   message,
+		RequestID: c.GetString("requestID"),
 	}
 
-	log.Printf("Error: %v", err)
+	// 5xx hataları error, diğerleri debug seviyesinde yazılır; erişim kaydı zaten durum kodunu içerir
+	if err != nil {
+		level := slog.LevelDebug
+		if statusCode >= 500 {
+			level = slog.LevelError
+		}
+		ctx := c.Request.Context()
+		logging.FromContext(ctx).Log(ctx, level, message, "status", statusCode, "error", err)
+	}
 
 	c.JSON(statusCode, errorResponse)
 }
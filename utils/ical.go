@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// iCalendar (RFC 5545) içerik satırı
+type ICalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// ICalWriter içerik satırlarını CRLF ile ve 75 baytta katlayarak yazar
+type ICalWriter struct {
+	buf bytes.Buffer
+}
+
+// Değeri olduğu gibi yazar (tarih, durum gibi kaçış gerektirmeyen alanlar için)
+func (w *ICalWriter) Line(name, value string) {
+	line := name + ":" + value
+	for len(line) > 75 {
+		cut := 75
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.buf.WriteString(line[:cut] + "\r\n")
+		line = " " + line[cut:]
+	}
+	w.buf.WriteString(line + "\r\n")
+}
+
+// Metin değerini kaçış karakterleriyle yazar
+func (w *ICalWriter) Text(name, value string) {
+	w.Line(name, ICalEscape(value))
+}
+
+func (w *ICalWriter) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+var icalUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func ICalEscape(s string) string {
+	return icalEscaper.Replace(s)
+}
+
+func ICalUnescape(s string) string {
+	return icalUnescaper.Replace(s)
+}
+
+// Verilen türdeki bileşenlerin (örneğin VTODO) özelliklerini döner.
+// İç içe bileşenlerin (örneğin VALARM) özellikleri dahil edilmez.
+func ParseICalComponents(data []byte, component string) ([][]ICalProperty, error) {
+	var result [][]ICalProperty
+	var current []ICalProperty
+	inside, depth := false, 0
+
+	for _, line := range unfoldICal(data) {
+		if line == "" {
+			continue
+		}
+		prop, err := parseICalLine(line)
+		if err != nil {
+			return nil, err
+		}
+		switch prop.Name {
+		case "BEGIN":
+			if inside {
+				depth++
+			} else if strings.EqualFold(prop.Value, component) {
+				inside, current = true, []ICalProperty{}
+			}
+		case "END":
+			if inside && depth > 0 {
+				depth--
+			} else if inside && strings.EqualFold(prop.Value, component) {
+				result = append(result, current)
+				inside = false
+			}
+		default:
+			if inside && depth == 0 {
+				current = append(current, prop)
+			}
+		}
+	}
+	if inside {
+		return nil, errors.New("unterminated " + component)
+	}
+	return result, nil
+}
+
+// Boşluk veya tab ile başlayan satırlar bir önceki satırın devamıdır
+func unfoldICal(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// NAME;PARAM=değer;...:DEĞER satırını ayrıştırır; tırnak içindeki ":" ve ";" ayırıcı sayılmaz
+func parseICalLine(line string) (ICalProperty, error) {
+	inQuotes := false
+	var parts []string
+	start := 0
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"':
+			inQuotes = !inQuotes
+		case ';', ':':
+			if inQuotes {
+				continue
+			}
+			parts = append(parts, line[start:i])
+			start = i + 1
+			if line[i] == ':' {
+				prop := ICalProperty{Name: strings.ToUpper(parts[0]), Params: map[string]string{}, Value: line[start:]}
+				for _, p := range parts[1:] {
+					if k, v, ok := strings.Cut(p, "="); ok {
+						prop.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+					}
+				}
+				return prop, nil
+			}
+		}
+	}
+	return ICalProperty{}, errors.New("invalid content line")
+}
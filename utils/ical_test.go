@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestICalWriterFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("çok uzun görev açıklaması, ", 12)
+	w := &ICalWriter{}
+	w.Line("BEGIN", "VTODO")
+	w.Text("SUMMARY", summary)
+	w.Line("END", "VTODO")
+	out := string(w.Bytes())
+
+	if !strings.HasSuffix(out, "\r\n") || strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Fatal("lines must end with CRLF")
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+	if len(lines) < 5 {
+		t.Fatalf("long line was not folded: %d lines", len(lines))
+	}
+	for i, line := range lines {
+		if len(line) > 75 {
+			t.Errorf("line %d is %d bytes", i, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line %d splits a UTF-8 sequence", i)
+		}
+	}
+
+	todos, err := ParseICalComponents(w.Bytes(), "VTODO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos) != 1 || todos[0][0].Name != "SUMMARY" || ICalUnescape(todos[0][0].Value) != summary {
+		t.Fatalf("unfolded = %+v", todos)
+	}
+}
+
+func TestICalEscapeRoundTrip(t *testing.T) {
+	for _, s := range []string{`a,b;c\d`, "iki\nsatır", `\n değil`, ""} {
+		if got := ICalUnescape(ICalEscape(s)); got != s {
+			t.Errorf("round trip %q = %q", s, got)
+		}
+	}
+	if got := ICalEscape("a,b;c\r\nd"); got != `a\,b\;c\nd` {
+		t.Errorf("escape = %q", got)
+	}
+}
+
+func TestParseICalComponents(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:etkinlik\r\nEND:VEVENT\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"SUMMARY;LANGUAGE=tr:Birinci\r\n" +
+		"DUE;TZID=\"Europe/Istanbul\":20261102T093000\r\n" +
+		"X-NOTE;X-P=\"a:b;c\":değer:iki nokta\r\n" +
+		"BEGIN:VALARM\r\nSUMMARY:alarm\r\nEND:VALARM\r\n" +
+		"END:VTODO\r\n" +
+		"begin:vtodo\nsummary:Kat\n\tlanmış\nEND:VTODO\n" +
+		"END:VCALENDAR\r\n"
+
+	todos, err := ParseICalComponents([]byte(data), "VTODO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("got %d todos", len(todos))
+	}
+	first := todos[0]
+	// VALARM içindeki SUMMARY ve VEVENT özellikleri dahil edilmez
+	if len(first) != 3 || first[0].Value != "Birinci" || first[0].Params["LANGUAGE"] != "tr" {
+		t.Fatalf("first = %+v", first)
+	}
+	if first[1].Params["TZID"] != "Europe/Istanbul" || first[1].Value != "20261102T093000" {
+		t.Fatalf("DUE = %+v", first[1])
+	}
+	if first[2].Params["X-P"] != "a:b;c" || first[2].Value != "değer:iki nokta" {
+		t.Fatalf("quoted param = %+v", first[2])
+	}
+	if todos[1][0].Name != "SUMMARY" || todos[1][0].Value != "Katlanmış" {
+		t.Fatalf("second = %+v", todos[1])
+	}
+
+	if _, err := ParseICalComponents([]byte("BEGIN:VTODO\r\nSUMMARY:x\r\n"), "VTODO"); err == nil {
+		t.Error("unterminated VTODO accepted")
+	}
+	if _, err := ParseICalComponents([]byte("BEGIN:VTODO\r\nno colon\r\nEND:VTODO\r\n"), "VTODO"); err == nil {
+		t.Error("invalid content line accepted")
+	}
+}
@@ -0,0 +1,51 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Derleme sırasında doldurulur:
+//
+//	go build -ldflags "-X priviatodolist/version.Commit=$(git rev-parse HEAD) -X priviatodolist/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Verilmezlerse Go'nun gömdüğü VCS bilgisi kullanılır.
+var (
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Çalışan sürümün bilgileri
+type Info struct {
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified,omitempty"`
+}
+
+func Get() Info {
+	info := Info{Commit: Commit, BuildTime: BuildTime, GoVersion: runtime.Version()}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+	return info
+}